@@ -1,78 +1,156 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
-	"github.com/tanmancan/label-it/v1/internal/common"
 	"github.com/tanmancan/label-it/v1/internal/config"
 	"github.com/tanmancan/label-it/v1/internal/gitapi"
 	"github.com/tanmancan/label-it/v1/internal/labeler"
 )
 
-// Display list of labels to be added, if found
+// Display number of pull requests and pages fetched from the API
+func printFetchSummary(pageInfo gitapi.PageInfo) {
+	fmt.Printf("Fetched %[1]d open pull request(s) across %[2]d page(s).\n", pageInfo.Count, pageInfo.Pages)
+	if pageInfo.Truncated == true {
+		fmt.Printf("Stopped at the limit of %[1]d pull request(s). See the -max-pulls option.\n", config.MaxPulls)
+	}
+}
+
+// Display number of API requests made, and how many were retried or rate limited
+func printRequestSummary() {
+	stats := gitapi.Stats()
+	fmt.Printf(
+		"Made %[1]d API request(s). Retried %[2]d request(s). Rate limited %[3]d time(s).\n",
+		stats.Requests,
+		stats.Retries,
+		stats.RateLimited,
+	)
+}
+
+// Display list of labels to be added or removed, if found
 func printLabelSummary(prLabels []gitapi.PrLabel) {
 	updateCount := len(prLabels)
 	fmt.Printf("Found %[1]d matching pull request.\n", updateCount)
-	fmt.Println("PR\tLabels")
-	fmt.Println("--\t------")
+	fmt.Println("PR\tLabels\tRemoved")
+	fmt.Println("--\t------\t-------")
 	for _, prLabel := range prLabels {
-		fmt.Printf("%[1]d\t%[2]s\n", prLabel.Issue, strings.Join(prLabel.Labels, ", "))
+		fmt.Printf(
+			"%[1]d\t%[2]s\t%[3]s\n",
+			prLabel.Issue,
+			strings.Join(prLabel.Labels, ", "),
+			strings.Join(prLabel.Remove, ", "),
+		)
+	}
+	fmt.Print("\n")
+}
+
+// Display list of pull requests that failed to process, if any
+func printErrorSummary(message string, err error) {
+	var prErrors labeler.PrErrors
+	if errors.As(err, &prErrors) == false {
+		return
+	}
+
+	fmt.Printf("%[1]s %[2]d pull request(s).\n", message, len(prErrors))
+	fmt.Println("PR\tError")
+	fmt.Println("--\t-----")
+	for _, prErr := range prErrors {
+		fmt.Printf("%[1]d\t%[2]s\n", prErr.Issue, prErr.Err)
 	}
 	fmt.Print("\n")
 }
 
 // Ask users for confirmation before applying labels
-func userConfirm() bool {
+func userConfirm() (bool, error) {
 	fmt.Println("Do you want to continue? (y/n)")
 
 	if config.AutoConfirm == true {
 		fmt.Println("y")
-		return true
+		return true, nil
 	}
 
 	var userInput string
 
 	_, err := fmt.Scanln(&userInput)
-	common.CheckErr(err)
+	if err != nil {
+		return false, err
+	}
 
 	switch strings.ToLower(userInput) {
 	case "y", "yes":
-		return true
+		return true, nil
 	case "n", "no":
-		return false
+		return false, nil
 	default:
 		return userConfirm()
 	}
 }
 
-func main() {
+// Loads configuration, checks rules against open pull requests and applies labels.
+// Pull requests that fail to process are displayed in a summary and returned as
+// labeler.PrErrors, while other pull requests continue to be processed
+func run() error {
 	err := config.SetupArgs()
 
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
+	}
+
+	err = config.LoadYaml()
+	if err != nil {
+		return err
+	}
+	defer printRequestSummary()
+
+	prList, pageInfo, err := gitapi.ListPulls()
+	if err != nil {
+		return err
 	}
-	config.LoadYaml()
+	printFetchSummary(pageInfo)
 
-	prList := gitapi.ListPulls()
-	prLabels := labeler.RuleParser(prList)
+	prLabels, parseErr := labeler.RuleParser(prList)
 
 	printLabelSummary(prLabels)
+	printErrorSummary("Failed to check", parseErr)
 
 	if config.DryRun == true {
 		fmt.Println("Perform dry run. Pull requests were not updated.")
-		return
+		return parseErr
 	}
 
 	if len(prLabels) == 0 {
-		return
+		return parseErr
 	}
 
-	confirm := userConfirm()
+	confirm, err := userConfirm()
+	if err != nil {
+		return err
+	}
 
 	if confirm == true {
-		labeler.LabelPr(prLabels)
+		labelErr := labeler.LabelPr(prLabels)
+		printErrorSummary("Failed to label", labelErr)
+		if labelErr != nil {
+			return labelErr
+		}
+	}
+
+	return parseErr
+}
+
+func main() {
+	err := run()
+
+	var prErrors labeler.PrErrors
+	switch {
+	case errors.As(err, &prErrors):
+		// Failed pull requests have already been displayed
+		os.Exit(1)
+	case err != nil:
+		fmt.Println(err)
+		os.Exit(1)
 	}
 }
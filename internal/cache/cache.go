@@ -0,0 +1,39 @@
+package cache
+
+import "sync"
+
+// A value loaded for a single key
+type entry struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// Once caches a value for each key for the duration of a run. Each value is
+// only loaded once, and a failed load is cached as well. The lock only guards
+// the entries, so values for different keys are loaded concurrently.
+// The zero value is an empty cache
+type Once struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// Get returns the value for a key, calling load if the key has not been loaded
+func (c *Once) Get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[string]*entry{}
+	}
+	e, found := c.entries[key]
+	if found == false {
+		e = &entry{}
+		c.entries[key] = e
+	}
+	c.mu.Unlock()
+
+	e.once.Do(func() {
+		e.value, e.err = load()
+	})
+
+	return e.value, e.err
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnce_Get(t *testing.T) {
+	c := &Once{}
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return "value", nil
+	}
+
+	for i := 0; i < 2; i++ {
+		value, err := c.Get("key", load)
+		if err != nil || value != "value" {
+			t.Errorf("Once.Get() = %v, %v, want value", value, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("Once.Get() loaded %d times, want 1", loads)
+	}
+}
+
+func TestOnce_GetError(t *testing.T) {
+	c := &Once{}
+	loads := 0
+	loadErr := errors.New("failed")
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Get("key", func() (interface{}, error) {
+			loads++
+			return nil, loadErr
+		})
+		if err != loadErr {
+			t.Errorf("Once.Get() error = %v, want %v", err, loadErr)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("Once.Get() loaded %d times, want the error to be cached", loads)
+	}
+}
+
+func TestOnce_GetConcurrent(t *testing.T) {
+	c := &Once{}
+	second := make(chan bool)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.Get("first", func() (interface{}, error) {
+			// Holds the load open until another key is loaded
+			select {
+			case <-second:
+			case <-time.After(2 * time.Second):
+				t.Errorf("Once.Get() should load other keys while a load is in progress")
+			}
+			return nil, nil
+		})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	c.Get("second", func() (interface{}, error) {
+		close(second)
+		return nil, nil
+	})
+	wg.Wait()
+}
@@ -2,11 +2,11 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
-	"log"
+	"net/url"
 	"os"
-
-	"github.com/tanmancan/label-it/v1/internal/common"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -15,27 +15,255 @@ import (
 var YamlConfig YamlConfigV1
 
 // RuleTypeString groups of rule types for string values.
-// Exact - the rule value must be an exact match of the compare value.
-// NoExact - a rule value must NOT be an exact match of the compare value.
-// Match - a regex pattern that must match a compare value.
-// NoMatch - a regex pattern that must NOT match a compare value
+// Each check accepts a single value or a list of values.
+// Exact - the compare value must be an exact match of any rule value.
+// NoExact - the compare value must NOT be an exact match of any rule value.
+// Match - any regex pattern must match the compare value.
+// NoMatch - no regex pattern may match the compare value
 type RuleTypeString struct {
-	Exact   string `yaml:"exact,omitempty"`
-	NoExact string `yaml:"no-exact,omitempty"`
-	Match   string `yaml:"match,omitempty"`
-	NoMatch string `yaml:"no-match,omitempty"`
+	Exact   StringList `yaml:"exact,omitempty"`
+	NoExact StringList `yaml:"no-exact,omitempty"`
+	Match   StringList `yaml:"match,omitempty"`
+	NoMatch StringList `yaml:"no-match,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeString) IsEmpty() bool {
+	return len(r.Exact) == 0 && len(r.NoExact) == 0 && len(r.Match) == 0 && len(r.NoMatch) == 0
+}
+
+// RuleTypeList groups of rule types for a list of string values.
+// Includes all string checks, which are validated against every value in the list.
+// Empty - if true, the list must be empty. If false, the list must not be empty
+type RuleTypeList struct {
+	RuleTypeString `yaml:",inline"`
+	Empty          *bool `yaml:"empty,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeList) IsEmpty() bool {
+	return r.RuleTypeString.IsEmpty() && r.Empty == nil
 }
 
 // RuleTypeInt groups of rule types for integer values
-// Exact - the rule value must be an exact match of the compare value.
-// NoExact - a rule value must NOT be an exact match of the compare value.
-// Match - a regex pattern that must match a compare value.
-// NoMatch - a regex pattern that must NOT match a compare value
+// Each check accepts a single value or a list of values.
+// Exact - the compare value must be an exact match of any rule value.
+// NoExact - the compare value must NOT be an exact match of any rule value.
+// Match - any regex pattern must match the compare value.
+// NoMatch - no regex pattern may match the compare value
 type RuleTypeInt struct {
-	Exact   int    `yaml:"exact,omitempty"`
-	NoExact int    `yaml:"no-exact,omitempty"`
-	Match   string `yaml:"match,omitempty"`
-	NoMatch string `yaml:"no-match,omitempty"`
+	Exact   IntList    `yaml:"exact,omitempty"`
+	NoExact IntList    `yaml:"no-exact,omitempty"`
+	Match   StringList `yaml:"match,omitempty"`
+	NoMatch StringList `yaml:"no-match,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeInt) IsEmpty() bool {
+	return len(r.Exact) == 0 && len(r.NoExact) == 0 && len(r.Match) == 0 && len(r.NoMatch) == 0
+}
+
+// RuleTypeFile groups of rule types for changed file paths.
+// Includes all string checks, as well as glob checks using gitignore style patterns.
+// Glob - any glob pattern must match a changed file path.
+// NoGlob - no glob pattern may match a changed file path.
+// Quantifier - how many changed files must pass the exact, match and glob checks. Defaults to any.
+// MinCount - minimum number of changed files that must pass the exact, match and glob checks.
+// Status - only check changed files with any of these statuses: added, removed, modified, renamed, copied, changed or unchanged.
+// PreviousFilename - also check the previous path of renamed files
+type RuleTypeFile struct {
+	RuleTypeString   `yaml:",inline"`
+	Glob             StringList   `yaml:"glob,omitempty"`
+	NoGlob           StringList   `yaml:"no-glob,omitempty"`
+	Quantifier       Quantifier   `yaml:"quantifier,omitempty"`
+	MinCount         int          `yaml:"min-count,omitempty"`
+	Status           FileStatuses `yaml:"status,omitempty"`
+	PreviousFilename bool         `yaml:"previous-filename,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeFile) IsEmpty() bool {
+	return r.RuleTypeString.IsEmpty() && len(r.Glob) == 0 && len(r.NoGlob) == 0 && len(r.Status) == 0
+}
+
+// RuleTypeCount groups of comparison checks for a numeric value.
+// Gt - the value must be greater than this number.
+// Gte - the value must be greater than or equal to this number.
+// Lt - the value must be less than this number.
+// Lte - the value must be less than or equal to this number
+type RuleTypeCount struct {
+	Gt  *int `yaml:"gt,omitempty"`
+	Gte *int `yaml:"gte,omitempty"`
+	Lt  *int `yaml:"lt,omitempty"`
+	Lte *int `yaml:"lte,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeCount) IsEmpty() bool {
+	return r.Gt == nil && r.Gte == nil && r.Lt == nil && r.Lte == nil
+}
+
+// RuleTypeSize groups of rule types for the size of a pull request.
+// Additions - number of lines added.
+// Deletions - number of lines removed.
+// Total - number of lines added and removed.
+// Files - number of changed files.
+// Exclude - glob patterns of changed files that are not counted
+type RuleTypeSize struct {
+	Additions RuleTypeCount `yaml:"additions,omitempty"`
+	Deletions RuleTypeCount `yaml:"deletions,omitempty"`
+	Total     RuleTypeCount `yaml:"total,omitempty"`
+	Files     RuleTypeCount `yaml:"files,omitempty"`
+	Exclude   StringList    `yaml:"exclude,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeSize) IsEmpty() bool {
+	return r.Additions.IsEmpty() && r.Deletions.IsEmpty() && r.Total.IsEmpty() && r.Files.IsEmpty()
+}
+
+// RuleTypeReview groups of rule types for pull request reviews. Only the latest
+// review state of each reviewer is used.
+// State - the overall review state must equal any of these values: approved, changes-requested, commented or none.
+// Approvals - number of reviewers that have approved the pull request.
+// ApprovedBy - any of these users must have approved the pull request.
+// ChangesRequestedBy - any of these users must have requested changes.
+// ReviewedBy - any of these users must have reviewed the pull request
+type RuleTypeReview struct {
+	State              ReviewStates  `yaml:"state,omitempty"`
+	Approvals          RuleTypeCount `yaml:"approvals,omitempty"`
+	ApprovedBy         StringList    `yaml:"approved-by,omitempty"`
+	ChangesRequestedBy StringList    `yaml:"changes-requested-by,omitempty"`
+	ReviewedBy         StringList    `yaml:"reviewed-by,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeReview) IsEmpty() bool {
+	return len(r.State) == 0 &&
+		r.Approvals.IsEmpty() &&
+		len(r.ApprovedBy) == 0 &&
+		len(r.ChangesRequestedBy) == 0 &&
+		len(r.ReviewedBy) == 0
+}
+
+// RuleTypeCheck checks for an individual commit status or check run.
+// Name - the exact name of the status context or check run.
+// Conclusion - the conclusion must equal any of these values. If empty, the check must exist
+type RuleTypeCheck struct {
+	Name       string     `yaml:"name"`
+	Conclusion StringList `yaml:"conclusion,omitempty"`
+}
+
+// UnmarshalYAML custom parser to validate check names in YAML
+func (r *RuleTypeCheck) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type ruleTypeCheck RuleTypeCheck
+	check := ruleTypeCheck{}
+
+	if err := unmarshal(&check); err != nil {
+		return err
+	}
+
+	if check.Name == "" {
+		return errors.New("Missing checks-rule check name")
+	}
+
+	*r = RuleTypeCheck(check)
+	return nil
+}
+
+// RuleTypeChecks groups of rule types for the commit statuses and check runs of the head commit.
+// State - the overall state must equal any of these values: success, failure, pending or none.
+// Checks - every check must be found with a matching conclusion
+type RuleTypeChecks struct {
+	State  ChecksStates    `yaml:"state,omitempty"`
+	Checks []RuleTypeCheck `yaml:"checks,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeChecks) IsEmpty() bool {
+	return len(r.State) == 0 && len(r.Checks) == 0
+}
+
+// RuleTypeCommit groups of rule types for the commits in a pull request.
+// Message - checks against the commit message.
+// Author - checks against the Github username of the commit author, or the
+// git author name if the commit is not linked to a Github user.
+// Quantifier - how many commits must pass the message and author checks. Defaults to any.
+// MinCount - minimum number of commits that must pass the message and author checks
+type RuleTypeCommit struct {
+	Message    RuleTypeString `yaml:"message,omitempty"`
+	Author     RuleTypeString `yaml:"author,omitempty"`
+	Quantifier Quantifier     `yaml:"quantifier,omitempty"`
+	MinCount   int            `yaml:"min-count,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeCommit) IsEmpty() bool {
+	return r.Message.IsEmpty() && r.Author.IsEmpty()
+}
+
+// RuleTypeComment groups of rule types for the issue and review comments on a pull request.
+// Text - checks against the comment text.
+// Author - checks against the Github username of the comment author.
+// Quantifier - how many comments must pass the text and author checks. Defaults to any.
+// MinCount - minimum number of comments that must pass the text and author checks.
+// Latest - only check the most recent comment.
+// Maintainers - only check comments from repository owners, members and collaborators
+type RuleTypeComment struct {
+	Text        RuleTypeString `yaml:"text,omitempty"`
+	Author      RuleTypeString `yaml:"author,omitempty"`
+	Quantifier  Quantifier     `yaml:"quantifier,omitempty"`
+	MinCount    int            `yaml:"min-count,omitempty"`
+	Latest      bool           `yaml:"latest,omitempty"`
+	Maintainers bool           `yaml:"maintainers,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided. The latest and maintainers filters
+// are checks on their own, requiring at least one comment to pass the filter
+func (r RuleTypeComment) IsEmpty() bool {
+	return r.Text.IsEmpty() && r.Author.IsEmpty() && r.Latest == false && r.Maintainers == false
+}
+
+// RuleTypeMergeable groups of rule types for the mergeable state of a pull request.
+// Conflicts - if true, the pull request must have merge conflicts. If false, it must be mergeable.
+// State - the mergeable state must equal any of these values, ex: clean, dirty, blocked, behind or unstable
+type RuleTypeMergeable struct {
+	Conflicts *bool      `yaml:"conflicts,omitempty"`
+	State     StringList `yaml:"state,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeMergeable) IsEmpty() bool {
+	return r.Conflicts == nil && len(r.State) == 0
+}
+
+// RuleTypeTeam groups of rule types for organization team membership.
+// Teams use the "org/team-slug" format.
+// Member - the compare user must be a member of any of these teams.
+// NoMember - the compare user must NOT be a member of any of these teams
+type RuleTypeTeam struct {
+	Member   StringList `yaml:"member,omitempty"`
+	NoMember StringList `yaml:"no-member,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeTeam) IsEmpty() bool {
+	return len(r.Member) == 0 && len(r.NoMember) == 0
+}
+
+// RuleTypeIssue groups of rule types for issues linked to a pull request.
+// Linked - if true, the pull request must link to an issue. If false, it must not link to any issues.
+// State - any linked issue must have one of these states, ex: open or closed.
+// Labels - checks against the combined labels of all linked issues.
+type RuleTypeIssue struct {
+	Linked *bool        `yaml:"linked,omitempty"`
+	State  StringList   `yaml:"state,omitempty"`
+	Labels RuleTypeList `yaml:"labels,omitempty"`
+}
+
+// IsEmpty checks if no checks are provided
+func (r RuleTypeIssue) IsEmpty() bool {
+	return r.Linked == nil && len(r.State) == 0 && r.Labels.IsEmpty()
 }
 
 // RuleTypeDate groups of rule types for date values
@@ -44,24 +272,78 @@ type RuleTypeDate struct {
 	DaysBefore int `yaml:"days-before,omitempty"`
 }
 
+// YamlRuleSet set of rule types that must all match a pull request.
+// Rule sets can be nested using condition blocks:
+// All - list of rule sets that must all match.
+// Any - list of rule sets where at least one must match.
+// Not - rule set that must NOT match.
+// Draft - the pull request draft status must equal this value.
+// Labels - checks against the labels currently on the pull request.
+// Reviewer - checks against the requested reviewer logins and teams.
+// Assignee - checks against the assignee logins.
+// Milestone - checks against the milestone title.
+// Association - checks against the author association, ex: FIRST_TIME_CONTRIBUTOR or MEMBER.
+// Mergeable - checks against the mergeable state.
+// CodeOwners - checks against the CODEOWNERS owners of the changed files.
+// Team - checks the team membership of the pull request creator.
+type YamlRuleSet struct {
+	Head        RuleTypeString    `yaml:"head-rule,omitempty"`
+	Base        RuleTypeString    `yaml:"base-rule,omitempty"`
+	Title       RuleTypeString    `yaml:"title-rule,omitempty"`
+	Body        RuleTypeString    `yaml:"body-rule,omitempty"`
+	User        RuleTypeString    `yaml:"user-rule,omitempty"`
+	Number      RuleTypeInt       `yaml:"number-rule,omitempty"`
+	File        RuleTypeFile      `yaml:"file-rule,omitempty"`
+	Size        RuleTypeSize      `yaml:"size-rule,omitempty"`
+	Draft       *bool             `yaml:"draft-rule,omitempty"`
+	Labels      RuleTypeList      `yaml:"labels-rule,omitempty"`
+	Reviewer    RuleTypeList      `yaml:"reviewer-rule,omitempty"`
+	Assignee    RuleTypeList      `yaml:"assignee-rule,omitempty"`
+	Review      RuleTypeReview    `yaml:"review-rule,omitempty"`
+	Checks      RuleTypeChecks    `yaml:"checks-rule,omitempty"`
+	Commit      RuleTypeCommit    `yaml:"commit-rule,omitempty"`
+	Comment     RuleTypeComment   `yaml:"comment-rule,omitempty"`
+	Milestone   RuleTypeList      `yaml:"milestone-rule,omitempty"`
+	Association RuleTypeString    `yaml:"association-rule,omitempty"`
+	Mergeable   RuleTypeMergeable `yaml:"mergeable-rule,omitempty"`
+	Team        RuleTypeTeam      `yaml:"team-rule,omitempty"`
+	CodeOwners  RuleTypeList      `yaml:"codeowners-rule,omitempty"`
+	Issue       RuleTypeIssue     `yaml:"issue-rule,omitempty"`
+	Created     RuleTypeDate      `yaml:"created-rule,omitempty"`
+	Updated     RuleTypeDate      `yaml:"updated-rule,omitempty"`
+	All         []YamlRuleSet     `yaml:"all,omitempty"`
+	Any         []YamlRuleSet     `yaml:"any,omitempty"`
+	Not         *YamlRuleSet      `yaml:"not,omitempty"`
+}
+
 // YamlRuleGroup rules for an individual label
+// RemoveWhenUnmatched - removes the label from pull requests that no longer match the rules
+// InheritLabels - copies the labels of linked issues that pass these checks onto the pull request,
+// instead of adding a single label.
 type YamlRuleGroup struct {
-	Label   string         `yaml:"label"`
-	Head    RuleTypeString `yaml:"head-rule,omitempty"`
-	Base    RuleTypeString `yaml:"base-rule,omitempty"`
-	Title   RuleTypeString `yaml:"title-rule,omitempty"`
-	Body    RuleTypeString `yaml:"body-rule,omitempty"`
-	User    RuleTypeString `yaml:"user-rule,omitempty"`
-	Number  RuleTypeInt    `yaml:"number-rule,omitempty"`
-	File    RuleTypeString `yaml:"file-rule,omitempty"`
-	Created RuleTypeDate   `yaml:"created-rule,omitempty"`
-	Updated RuleTypeDate   `yaml:"updated-rule,omitempty"`
-}
-
-// YamlGithubAccess stores user and access token for Github api authentication
+	Label               string         `yaml:"label"`
+	RemoveWhenUnmatched bool           `yaml:"remove-when-unmatched,omitempty"`
+	InheritLabels       RuleTypeString `yaml:"inherit-labels,omitempty"`
+	YamlRuleSet         `yaml:",inline"`
+}
+
+// YamlGithubAccess stores credentials for Github api authentication.
+// Either an access token, or Github App credentials must be provided.
+// User - optional username. If provided, the token is sent using basic authentication.
+// AppID - ID of the Github App.
+// InstallationID - ID of the Github App installation on the repository owner.
+// PrivateKey - path to the Github App private key PEM file.
 type YamlGithubAccess struct {
-	User  string `yaml:"user"`
-	Token string `yaml:"token"`
+	User           string `yaml:"user"`
+	Token          string `yaml:"token"`
+	AppID          string `yaml:"app-id,omitempty"`
+	InstallationID string `yaml:"installation-id,omitempty"`
+	PrivateKey     string `yaml:"private-key,omitempty"`
+}
+
+// IsApp checks if access is configured to authenticate as a Github App
+func (a YamlGithubAccess) IsApp() bool {
+	return a.AppID != "" || a.InstallationID != "" || a.PrivateKey != ""
 }
 
 // Parses access values and checks for env variables if provided
@@ -83,8 +365,11 @@ func parseAccess(v string) (string, error) {
 // UnmarshalYAML custom parser for access data in YAML
 func (a *YamlGithubAccess) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var access struct {
-		User  string
-		Token string
+		User           string
+		Token          string
+		AppID          string `yaml:"app-id"`
+		InstallationID string `yaml:"installation-id"`
+		PrivateKey     string `yaml:"private-key"`
 	}
 
 	err := unmarshal(&access)
@@ -93,18 +378,29 @@ func (a *YamlGithubAccess) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		return err
 	}
 
-	if access.User == "" {
-		return errors.New("Missing access user")
+	app := YamlGithubAccess{
+		AppID:          access.AppID,
+		InstallationID: access.InstallationID,
+		PrivateKey:     access.PrivateKey,
+	}
+
+	if app.IsApp() {
+		return a.unmarshalApp(app)
 	}
 
 	if access.Token == "" {
 		return errors.New("Missing access token")
 	}
 
-	parsedUser, userErr := parseAccess(access.User)
+	// User is optional. Tokens without a user are sent as a bearer token
+	if access.User != "" {
+		parsedUser, userErr := parseAccess(access.User)
 
-	if userErr != nil {
-		return userErr
+		if userErr != nil {
+			return userErr
+		}
+
+		a.User = parsedUser
 	}
 
 	parsedToken, tokenErr := parseAccess(access.Token)
@@ -113,34 +409,171 @@ func (a *YamlGithubAccess) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		return tokenErr
 	}
 
-	a.User = parsedUser
 	a.Token = parsedToken
 	return nil
 }
 
+// GithubTokenEnv env variable used as the access token if no access is configured.
+// Github Actions provides this token to workflows
+const GithubTokenEnv = "GITHUB_TOKEN"
+
+// Validates that credentials are available. If the access configuration is
+// omitted, the GITHUB_TOKEN env variable is used as a token
+func validateAccess(access YamlGithubAccess) (YamlGithubAccess, error) {
+	if access.IsApp() || access.Token != "" {
+		return access, nil
+	}
+
+	if envToken := os.Getenv(GithubTokenEnv); envToken != "" {
+		access.Token = envToken
+		return access, nil
+	}
+
+	return access, fmt.Errorf(
+		"Missing access configuration. Provide an access token, Github App credentials, or set the %[1]s env variable",
+		GithubTokenEnv,
+	)
+}
+
+// Validates and parses Github App credentials
+func (a *YamlGithubAccess) unmarshalApp(app YamlGithubAccess) error {
+	if app.AppID == "" {
+		return errors.New("Missing access app-id")
+	}
+
+	if app.InstallationID == "" {
+		return errors.New("Missing access installation-id")
+	}
+
+	if app.PrivateKey == "" {
+		return errors.New("Missing access private-key")
+	}
+
+	parsedAppID, appIDErr := parseAccess(app.AppID)
+
+	if appIDErr != nil {
+		return appIDErr
+	}
+
+	parsedInstallationID, installationIDErr := parseAccess(app.InstallationID)
+
+	if installationIDErr != nil {
+		return installationIDErr
+	}
+
+	parsedPrivateKey, privateKeyErr := parseAccess(app.PrivateKey)
+
+	if privateKeyErr != nil {
+		return privateKeyErr
+	}
+
+	a.AppID = parsedAppID
+	a.InstallationID = parsedInstallationID
+	a.PrivateKey = parsedPrivateKey
+	return nil
+}
+
+// YamlLinkedIssues options for finding the issues linked to a pull request.
+// Issues are referenced in the pull request body, ex: "Fixes #123" or "owner/repo#123".
+// ClosingOnly - only use references with a closing keyword, ex: fixes, closes or resolves.
+// Timeline - also use issues that reference the pull request, from the pull request timeline.
+type YamlLinkedIssues struct {
+	ClosingOnly bool `yaml:"closing-only,omitempty"`
+	Timeline    bool `yaml:"timeline,omitempty"`
+}
+
 // YamlConfigV1 interface used to unmarshal YAML configuration
+// APIURL - base URL of the Github API, used for Github Enterprise Server.
+// CABundle - path to a PEM file of additional certificate authorities to trust.
+// InsecureSkipVerify - skips TLS certificate verification. Only use for internal test instances.
+// CodeOwnersPath - path to a local CODEOWNERS file. If empty, the file is read from the base branch.
+// LinkedIssues - options for finding the issues linked to a pull request.
 type YamlConfigV1 struct {
-	APIVersion string           `yaml:"apiVersion"`
-	Access     YamlGithubAccess `yaml:"access"`
-	Owner      string           `yaml:"owner"`
-	Repo       string           `yaml:"repo"`
-	Rules      []YamlRuleGroup  `yaml:"rules"`
+	APIVersion         string           `yaml:"apiVersion"`
+	Access             YamlGithubAccess `yaml:"access"`
+	APIURL             string           `yaml:"api-url,omitempty"`
+	CABundle           string           `yaml:"ca-bundle,omitempty"`
+	InsecureSkipVerify bool             `yaml:"insecure-skip-verify,omitempty"`
+	Owner              string           `yaml:"owner"`
+	Repo               string           `yaml:"repo"`
+	CodeOwnersPath     string           `yaml:"codeowners-path,omitempty"`
+	LinkedIssues       YamlLinkedIssues `yaml:"linked-issues,omitempty"`
+	Rules              []YamlRuleGroup  `yaml:"rules"`
+}
+
+// APIURLEnv env variable used to override the Github API URL
+const APIURLEnv = "LABEL_IT_API_URL"
+
+// Resolves the Github API URL. The -api-url flag takes precedence, followed
+// by the LABEL_IT_API_URL env variable, then the api-url YAML value.
+func resolveAPIURL(yamlURL string) (string, error) {
+	apiURL := yamlURL
+
+	if envURL := os.Getenv(APIURLEnv); envURL != "" {
+		apiURL = envURL
+	}
+
+	if APIURL != "" {
+		apiURL = APIURL
+	}
+
+	if apiURL == "" {
+		return "", nil
+	}
+
+	apiURL, err := parseAccess(apiURL)
+	if err != nil {
+		return "", err
+	}
+
+	parsedURL, err := url.Parse(apiURL)
+	if err != nil {
+		return "", err
+	}
+
+	if (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") || parsedURL.Host == "" {
+		return "", fmt.Errorf("Invalid api-url \"%[1]s\". Must be an absolute http or https URL", apiURL)
+	}
+
+	return strings.TrimSuffix(apiURL, "/"), nil
 }
 
 // Validates YAML with current package version
-func validateVersion(ver string) {
+func validateVersion(ver string) error {
 	if ver != APIVersion {
-		log.Fatal("Invalid config file version. Current tool requires version", APIVersion)
+		return fmt.Errorf("Invalid config file version. Current tool requires version %[1]s", APIVersion)
 	}
+
+	return nil
 }
 
 // LoadYaml load configuration from a given yaml file
-func LoadYaml() {
+func LoadYaml() error {
 	dat, err := ioutil.ReadFile(YamlPath)
-	common.CheckErr(err)
+	if err != nil {
+		return err
+	}
 
 	parseerr := yaml.UnmarshalStrict(dat, &YamlConfig)
-	common.CheckErr(parseerr)
+	if parseerr != nil {
+		return parseerr
+	}
+
+	access, accesserr := validateAccess(YamlConfig.Access)
+	if accesserr != nil {
+		return accesserr
+	}
+	YamlConfig.Access = access
+
+	apiURL, urlerr := resolveAPIURL(YamlConfig.APIURL)
+	if urlerr != nil {
+		return urlerr
+	}
+	YamlConfig.APIURL = apiURL
+
+	if err := validateRules(YamlConfig.Rules); err != nil {
+		return err
+	}
 
-	validateVersion(APIVersion)
+	return validateVersion(APIVersion)
 }
@@ -1,10 +1,13 @@
 package config_test
 
 import (
+	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tanmancan/label-it/v1/internal/config"
+	"gopkg.in/yaml.v2"
 )
 
 func assertEqual(expectedValue interface{}, givenValue interface{}, t *testing.T) {
@@ -13,9 +16,17 @@ func assertEqual(expectedValue interface{}, givenValue interface{}, t *testing.T
 	}
 }
 
+func assertList(expectedValue []string, givenValue config.StringList, t *testing.T) {
+	if strings.Join(givenValue, "\n") != strings.Join(expectedValue, "\n") {
+		t.Errorf("Expected value: %[1]v Found value: %[2]v", expectedValue, givenValue)
+	}
+}
+
 func TestYamlConfigLoad(t *testing.T) {
 	config.YamlPath = "./config_test.yaml"
-	config.LoadYaml()
+	if err := config.LoadYaml(); err != nil {
+		t.Fatalf("LoadYaml() error = %v", err)
+	}
 	t.Cleanup(func() {
 		config.YamlConfig = config.YamlConfigV1{}
 	})
@@ -64,16 +75,16 @@ func TestYamlConfigLoad(t *testing.T) {
 
 		for _, stringRule := range ruleStringTestData {
 			t.Run("ExactCheck", func(t *testing.T) {
-				assertEqual(exact, stringRule.rule.Exact, t)
+				assertList([]string{exact}, stringRule.rule.Exact, t)
 			})
 			t.Run("NoExactCheck", func(t *testing.T) {
-				assertEqual(noExact, stringRule.rule.NoExact, t)
+				assertList([]string{noExact}, stringRule.rule.NoExact, t)
 			})
 			t.Run("MatchCheck", func(t *testing.T) {
-				assertEqual(match, stringRule.rule.Match, t)
+				assertList([]string{match}, stringRule.rule.Match, t)
 			})
 			t.Run("NoMatchCheck", func(t *testing.T) {
-				assertEqual(noMatch, stringRule.rule.NoMatch, t)
+				assertList([]string{noMatch}, stringRule.rule.NoMatch, t)
 			})
 		}
 
@@ -108,7 +119,9 @@ func TestYamlGithubAccessUnmarshal(t *testing.T) {
 	os.Setenv("GIT_TEST_USER", osUser)
 
 	config.YamlPath = "./config_test_env.yaml"
-	config.LoadYaml()
+	if err := config.LoadYaml(); err != nil {
+		t.Fatalf("LoadYaml() error = %v", err)
+	}
 
 	t.Cleanup(func() {
 		config.YamlConfig = config.YamlConfigV1{}
@@ -124,3 +137,508 @@ func TestYamlGithubAccessUnmarshal(t *testing.T) {
 		t.Errorf("config.YamlConfig.Access.User should be %s", osUser)
 	}
 }
+
+func TestYamlConfigLoadMissingFile(t *testing.T) {
+	config.YamlPath = "./does_not_exist.yaml"
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	if err := config.LoadYaml(); err == nil {
+		t.Errorf("LoadYaml should return an error if the config file does not exist")
+	}
+}
+
+func TestYamlConfigInvalidPattern(t *testing.T) {
+	config.YamlPath = "./config_test_pattern.yaml"
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	err := config.LoadYaml()
+	if err == nil {
+		t.Fatalf("LoadYaml should return an error for an invalid regex pattern")
+	}
+	if strings.Contains(err.Error(), "\"^(fix\" for label \"invalid\"") == false {
+		t.Errorf("LoadYaml() error = %v, want the invalid pattern and label", err)
+	}
+	if strings.Contains(err.Error(), "glob \"foo/[\" for label \"invalid-glob\"") == false {
+		t.Errorf("LoadYaml() error = %v, want the invalid glob and label", err)
+	}
+}
+
+func TestYamlConfigInheritRemove(t *testing.T) {
+	config.YamlPath = "./config_test_inherit.yaml"
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	err := config.LoadYaml()
+	if err == nil {
+		t.Fatalf("LoadYaml should return an error for inherit-labels with remove-when-unmatched")
+	}
+	if strings.Contains(err.Error(), "remove-when-unmatched can not be used with inherit-labels") == false {
+		t.Errorf("LoadYaml() error = %v", err)
+	}
+}
+
+func TestYamlConfigInvalidQuantifier(t *testing.T) {
+	header := "apiVersion: v1\naccess:\n  token: testingTokenAbcd\nowner: tanmancan\nrepo: github-api-sandbox\nrules:\n  - label: quantifier\n"
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr string
+	}{
+		{"file none with min-count", "    file-rule:\n      glob: docs/\n      quantifier: none\n      min-count: 2\n", "file-rule min-count can not be used with the none quantifier"},
+		{"file quantifier without checks", "    file-rule:\n      no-glob: docs/\n      quantifier: all\n", "file-rule quantifier and min-count require"},
+		{"file min-count with status only", "    file-rule:\n      status: added\n      min-count: 2\n", "file-rule quantifier and min-count require"},
+		{"nested commit none with min-count", "    not:\n      commit-rule:\n        message:\n          match: ^fix\n        quantifier: none\n        min-count: 1\n", "commit-rule min-count can not be used"},
+		{"comment quantifier without checks", "    any:\n      - comment-rule:\n          quantifier: all\n", "comment-rule quantifier and min-count require"},
+		{"valid", "    file-rule:\n      glob: docs/\n      quantifier: all\n      min-count: 2\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ioutil.TempFile("", "label-it-*.yaml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(file.Name())
+			file.WriteString(header + tt.rule)
+			file.Close()
+
+			config.YamlPath = file.Name()
+			t.Cleanup(func() {
+				config.YamlConfig = config.YamlConfigV1{}
+			})
+
+			err = config.LoadYaml()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LoadYaml() error = %v", err)
+				}
+				return
+			}
+			if err == nil || strings.Contains(err.Error(), tt.wantErr) == false {
+				t.Errorf("LoadYaml() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestYamlConfigAPIURL(t *testing.T) {
+	config.YamlPath = "./config_test.yaml"
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+		config.APIURL = ""
+		os.Unsetenv(config.APIURLEnv)
+	})
+
+	tests := []struct {
+		name    string
+		env     string
+		flag    string
+		want    string
+		wantErr bool
+	}{
+		{"defaults to empty", "", "", "", false},
+		{"uses env variable", "https://github.example.com/", "", "https://github.example.com", false},
+		{"flag overrides env variable", "https://github.example.com", "https://ghe.example.org/api/v3", "https://ghe.example.org/api/v3", false},
+		{"rejects relative url", "github.example.com", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(config.APIURLEnv, tt.env)
+			config.APIURL = tt.flag
+			config.YamlConfig = config.YamlConfigV1{}
+
+			err := config.LoadYaml()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadYaml() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				assertEqual(tt.want, config.YamlConfig.APIURL, t)
+			}
+		})
+	}
+}
+
+func TestYamlGithubAccessUnmarshalApp(t *testing.T) {
+	os.Setenv("GIT_TEST_INSTALLATION_ID", "5678")
+
+	config.YamlPath = "./config_test_app.yaml"
+	if err := config.LoadYaml(); err != nil {
+		t.Fatalf("LoadYaml() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+		os.Unsetenv("GIT_TEST_INSTALLATION_ID")
+	})
+
+	access := config.YamlConfig.Access
+
+	if access.IsApp() != true {
+		t.Errorf("config.YamlConfig.Access.IsApp() should be true")
+	}
+
+	assertEqual("1234", access.AppID, t)
+	assertEqual("5678", access.InstallationID, t)
+	assertEqual("./label-it-app.private-key.pem", access.PrivateKey, t)
+	assertEqual("", access.Token, t)
+}
+
+func TestYamlGithubAccessTokenOnly(t *testing.T) {
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+		os.Unsetenv("GIT_TEST_TOKEN")
+		os.Unsetenv(config.GithubTokenEnv)
+	})
+
+	tests := []struct {
+		name      string
+		path      string
+		env       map[string]string
+		wantToken string
+		wantErr   bool
+	}{
+		{
+			"token without user",
+			"./config_test_token.yaml",
+			map[string]string{"GIT_TEST_TOKEN": "github_pat_abcd"},
+			"github_pat_abcd",
+			false,
+		},
+		{
+			"falls back to GITHUB_TOKEN",
+			"./config_test_noaccess.yaml",
+			map[string]string{config.GithubTokenEnv: "ghs_abcd"},
+			"ghs_abcd",
+			false,
+		},
+		{
+			"configured token takes precedence over GITHUB_TOKEN",
+			"./config_test_token.yaml",
+			map[string]string{"GIT_TEST_TOKEN": "github_pat_abcd", config.GithubTokenEnv: "ghs_abcd"},
+			"github_pat_abcd",
+			false,
+		},
+		{
+			"errors without any credentials",
+			"./config_test_noaccess.yaml",
+			map[string]string{},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Unsetenv("GIT_TEST_TOKEN")
+			os.Unsetenv(config.GithubTokenEnv)
+			for key, val := range tt.env {
+				os.Setenv(key, val)
+			}
+			config.YamlConfig = config.YamlConfigV1{}
+			config.YamlPath = tt.path
+
+			err := config.LoadYaml()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadYaml() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertEqual(tt.wantToken, config.YamlConfig.Access.Token, t)
+			assertEqual("", config.YamlConfig.Access.User, t)
+		})
+	}
+}
+
+// Finds a rule group in the loaded config by label
+func findRule(label string, t *testing.T) config.YamlRuleGroup {
+	for _, rule := range config.YamlConfig.Rules {
+		if rule.Label == label {
+			return rule
+		}
+	}
+
+	t.Fatalf("No rule found with label %[1]q", label)
+	return config.YamlRuleGroup{}
+}
+
+func TestYamlConfigRules(t *testing.T) {
+	config.YamlPath = "./config_test.yaml"
+	if err := config.LoadYaml(); err != nil {
+		t.Fatalf("LoadYaml() error = %v", err)
+	}
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	tests := []struct {
+		label string
+		check func(rule config.YamlRuleGroup, t *testing.T)
+	}{
+		{"my-label-name", func(rule config.YamlRuleGroup, t *testing.T) {
+			if rule.Draft != nil {
+				t.Errorf("Expected draft-rule to be nil when not provided")
+			}
+		}},
+		{"hotfix", func(rule config.YamlRuleGroup, t *testing.T) {
+			if len(rule.Any) != 2 {
+				t.Fatalf("rule.Any should have 2 conditions, found %d", len(rule.Any))
+			}
+			assertList([]string{"^(hotfix)"}, rule.Any[0].Title.Match, t)
+			assertList([]string{"^(hotfix/)"}, rule.Any[1].Head.Match, t)
+
+			if len(rule.All) != 1 {
+				t.Fatalf("rule.All should have 1 condition, found %d", len(rule.All))
+			}
+			assertList([]string{"octocat"}, rule.All[0].User.NoExact, t)
+
+			if rule.Not == nil {
+				t.Fatalf("rule.Not should not be nil")
+			}
+			assertList([]string{"develop"}, rule.Not.Base.Exact, t)
+		}},
+		{"list-values", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"alice", "bob"}, rule.User.Exact, t)
+			assertList([]string{"^(bot-)"}, rule.User.NoMatch, t)
+
+			if len(rule.Number.Exact) != 2 || rule.Number.Exact[0] != 42 || rule.Number.Exact[1] != 43 {
+				t.Errorf("rule.Number.Exact should be [42 43], found %v", rule.Number.Exact)
+			}
+			if len(rule.Number.NoExact) != 1 || rule.Number.NoExact[0] != 10 {
+				t.Errorf("rule.Number.NoExact should be [10], found %v", rule.Number.NoExact)
+			}
+		}},
+		{"frontend", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"src/frontend/**/*.tsx"}, rule.File.Glob, t)
+			assertList([]string{"docs/", "*.md"}, rule.File.NoGlob, t)
+			assertList([]string{"^(vendor/)"}, rule.File.NoMatch, t)
+		}},
+		{"docs-only", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertEqual(config.QuantifierAll, rule.File.Quantifier, t)
+		}},
+		{"migrations", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertEqual(3, rule.File.MinCount, t)
+		}},
+		{"moved-migrations", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"added", "renamed"}, config.StringList(rule.File.Status), t)
+			assertEqual(true, rule.File.PreviousFilename, t)
+		}},
+		{"size/XL", func(rule config.YamlRuleGroup, t *testing.T) {
+			if rule.Size.Total.Gte == nil || *rule.Size.Total.Gte != 1000 {
+				t.Errorf("Expected total gte of 1000, found %v", rule.Size.Total.Gte)
+			}
+			if rule.Size.Files.Gt == nil || *rule.Size.Files.Gt != 0 {
+				t.Errorf("Expected files gt of 0, found %v", rule.Size.Files.Gt)
+			}
+			if rule.Size.Additions.IsEmpty() == false || rule.Size.Total.Lt != nil {
+				t.Errorf("Expected unset size checks to be nil")
+			}
+			assertList([]string{"vendor/", "*.lock"}, rule.Size.Exclude, t)
+		}},
+		{"WIP", func(rule config.YamlRuleGroup, t *testing.T) {
+			if rule.Draft == nil || *rule.Draft != true {
+				t.Errorf("Expected draft-rule to be true, found %v", rule.Draft)
+			}
+		}},
+		{"ready-to-merge", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"approved"}, rule.Labels.Exact, t)
+			assertList([]string{"do-not-merge", "WIP"}, rule.Labels.NoExact, t)
+		}},
+		{"approved", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"approved"}, config.StringList(rule.Review.State), t)
+			assertList([]string{"octocat", "hubot"}, rule.Review.ApprovedBy, t)
+			if rule.Review.Approvals.Gte == nil || *rule.Review.Approvals.Gte != 2 {
+				t.Errorf("Expected approvals gte of 2, found %v", rule.Review.Approvals.Gte)
+			}
+		}},
+		{"unassigned", func(rule config.YamlRuleGroup, t *testing.T) {
+			if rule.Assignee.Empty == nil || *rule.Assignee.Empty != true {
+				t.Errorf("Expected assignee-rule empty to be true, found %v", rule.Assignee.Empty)
+			}
+			assertList([]string{"octocat/frontend-team"}, rule.Reviewer.Exact, t)
+			if rule.Reviewer.Empty != nil {
+				t.Errorf("Expected reviewer-rule empty to be nil when not provided")
+			}
+		}},
+		{"ci-failed", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"failure"}, config.StringList(rule.Checks.State), t)
+			if len(rule.Checks.Checks) != 2 {
+				t.Fatalf("Expected 2 checks, found %d", len(rule.Checks.Checks))
+			}
+			assertEqual("build", rule.Checks.Checks[0].Name, t)
+			assertList([]string{"failure", "timed_out"}, rule.Checks.Checks[0].Conclusion, t)
+			assertEqual("lint", rule.Checks.Checks[1].Name, t)
+			assertList([]string{}, rule.Checks.Checks[1].Conclusion, t)
+		}},
+		{"breaking-change", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"BREAKING CHANGE", `^[a-z]+(\(.+\))?!:`}, rule.Commit.Message.Match, t)
+			assertList([]string{"dependabot[bot]"}, rule.Commit.Author.NoExact, t)
+			assertEqual(config.QuantifierAny, rule.Commit.Quantifier, t)
+		}},
+		{"first-timer", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"FIRST_TIME_CONTRIBUTOR", "FIRST_TIMER"}, rule.Association.Exact, t)
+			assertList([]string{"Backlog"}, rule.Milestone.NoExact, t)
+			if rule.Milestone.Empty == nil || *rule.Milestone.Empty != false {
+				t.Errorf("Expected milestone-rule empty to be false, found %v", rule.Milestone.Empty)
+			}
+		}},
+		{"has-conflicts", func(rule config.YamlRuleGroup, t *testing.T) {
+			if rule.Mergeable.Conflicts == nil || *rule.Mergeable.Conflicts != true {
+				t.Errorf("Expected mergeable-rule conflicts to be true, found %v", rule.Mergeable.Conflicts)
+			}
+		}},
+		{"platform-team", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"octo-org/platform"}, rule.Team.Member, t)
+			assertList([]string{"octo-org/contractors"}, rule.Team.NoMember, t)
+		}},
+		{"team/{name}", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"^@octo-org/"}, rule.CodeOwners.Match, t)
+		}},
+		{"needs-issue", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertEqual(false, *rule.Issue.Linked, t)
+		}},
+		// Rules using inherit-labels do not have a label
+		{"", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"^area/"}, rule.InheritLabels.Match, t)
+			assertList([]string{"open"}, rule.Issue.State, t)
+			assertList([]string{"wontfix"}, rule.Issue.Labels.NoExact, t)
+		}},
+		{"needs-qa", func(rule config.YamlRuleGroup, t *testing.T) {
+			assertList([]string{"(?m)^/label needs-qa"}, rule.Comment.Text.Match, t)
+			assertList([]string{"\\[bot\\]$"}, rule.Comment.Author.NoMatch, t)
+			assertEqual(1, rule.Comment.MinCount, t)
+			assertEqual(true, rule.Comment.Latest, t)
+			assertEqual(true, rule.Comment.Maintainers, t)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			tt.check(findRule(tt.label, t), t)
+		})
+	}
+}
+
+func TestYamlConfigOptions(t *testing.T) {
+	config.YamlPath = "./config_test.yaml"
+	if err := config.LoadYaml(); err != nil {
+		t.Fatalf("LoadYaml() error = %v", err)
+	}
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	assertEqual("", config.YamlConfig.CodeOwnersPath, t)
+	assertEqual(true, config.YamlConfig.LinkedIssues.ClosingOnly, t)
+	assertEqual(true, config.YamlConfig.LinkedIssues.Timeline, t)
+}
+
+func TestRuleTypeCheckUnmarshal(t *testing.T) {
+	rule := config.RuleTypeChecks{}
+	err := yaml.UnmarshalStrict([]byte("checks:\n  - conclusion: failure"), &rule)
+	if err == nil || strings.Contains(err.Error(), "Missing checks-rule check name") == false {
+		t.Errorf("yaml.UnmarshalStrict() error = %v, want missing check name error", err)
+	}
+}
+
+func TestQuantifierUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    config.Quantifier
+		wantErr bool
+	}{
+		{"any", "quantifier: any", config.QuantifierAny, false},
+		{"all", "quantifier: all", config.QuantifierAll, false},
+		{"none", "quantifier: none", config.QuantifierNone, false},
+		{"empty defaults to any", "quantifier: \"\"", config.QuantifierAny, false},
+		{"invalid value", "quantifier: some", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.RuleTypeFile{}
+			err := yaml.UnmarshalStrict([]byte(tt.value), &rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("yaml.UnmarshalStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertEqual(tt.want, rule.Quantifier, t)
+		})
+	}
+}
+
+func TestReviewStatesUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"single state", "state: approved", []string{"approved"}, false},
+		{"list of states", "state: [changes-requested, commented, none]", []string{"changes-requested", "commented", "none"}, false},
+		{"api spelling", "state: APPROVED", nil, true},
+		{"invalid state", "state: [approved, dismissed]", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.RuleTypeReview{}
+			err := yaml.UnmarshalStrict([]byte(tt.value), &rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("yaml.UnmarshalStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == false {
+				assertList(tt.want, config.StringList(rule.State), t)
+			}
+		})
+	}
+}
+
+func TestChecksStatesUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"single state", "state: failure", []string{"failure"}, false},
+		{"list of states", "state: [pending, success, none]", []string{"pending", "success", "none"}, false},
+		{"typo", "state: failed", nil, true},
+		{"api spelling", "state: [failure, SUCCESS]", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.RuleTypeChecks{}
+			err := yaml.UnmarshalStrict([]byte(tt.value), &rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("yaml.UnmarshalStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == false {
+				assertList(tt.want, config.StringList(rule.State), t)
+			}
+		})
+	}
+}
+
+func TestFileStatusesUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"single status", "status: added", []string{"added"}, false},
+		{"list of statuses", "status: [removed, modified, renamed, copied, changed, unchanged]", []string{"removed", "modified", "renamed", "copied", "changed", "unchanged"}, false},
+		{"unknown status", "status: new", nil, true},
+		{"abbreviated status", "status: [added, add]", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.RuleTypeFile{}
+			err := yaml.UnmarshalStrict([]byte(tt.value), &rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("yaml.UnmarshalStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == false {
+				assertList(tt.want, config.StringList(rule.Status), t)
+			}
+		})
+	}
+}
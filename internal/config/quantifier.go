@@ -0,0 +1,35 @@
+package config
+
+import "fmt"
+
+// Quantifier determines how many values must pass a check
+type Quantifier string
+
+const (
+	// QuantifierAny at least one value must pass the check
+	QuantifierAny Quantifier = "any"
+	// QuantifierAll every value must pass the check
+	QuantifierAll Quantifier = "all"
+	// QuantifierNone no value may pass the check
+	QuantifierNone Quantifier = "none"
+)
+
+// UnmarshalYAML custom parser to validate quantifier values in YAML
+func (q *Quantifier) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	switch Quantifier(value) {
+	case QuantifierAny, QuantifierAll, QuantifierNone:
+		*q = Quantifier(value)
+	case "":
+		*q = QuantifierAny
+	default:
+		return fmt.Errorf("Invalid quantifier \"%[1]s\". Must be one of any, all or none", value)
+	}
+
+	return nil
+}
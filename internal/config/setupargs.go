@@ -10,15 +10,19 @@ import (
 // YamlPath path to the yaml config file provided via a flag
 var YamlPath string
 
-// ShowHelp value of flag to show help
-var ShowHelp bool
-
 // DryRun outputs labels to be added to pr based on rules without making an API call
 var DryRun bool
 
 // AutoConfirm value of flag to used to auto confirm any prompt
 var AutoConfirm bool
 
+// APIURL value of flag used to override the Github API URL
+var APIURL string
+
+// MaxPulls upper bound on the number of open pull requests fetched from the API.
+// A value of 0 or less fetches every open pull request
+var MaxPulls int
+
 // SetupArgs sets up flags and help text
 func SetupArgs() error {
 	flag.Usage = func() {
@@ -30,11 +34,12 @@ func SetupArgs() error {
 
 	var showVersion bool
 
-	flag.BoolVar(&ShowHelp, "-help", false, "Display the help text")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 	flag.StringVar(&YamlPath, "c", "", "Path to the yaml file")
 	flag.BoolVar(&DryRun, "dry", false, "Outputs list of pull request and matched labels. Does not call the API")
 	flag.BoolVar(&AutoConfirm, "y", false, "Auto confirms user prompt")
+	flag.StringVar(&APIURL, "api-url", "", "Github API URL. Overrides the api-url config and LABEL_IT_API_URL env variable")
+	flag.IntVar(&MaxPulls, "max-pulls", 1000, "Maximum number of open pull requests to fetch. Use 0 to fetch all")
 	flag.Parse()
 
 	if showVersion == true {
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parses a string or list of strings in YAML, and checks that each value is allowed
+func unmarshalStates(unmarshal func(interface{}) error, name string, allowed StringList) (StringList, error) {
+	var states StringList
+
+	if err := unmarshal(&states); err != nil {
+		return nil, err
+	}
+
+	for _, state := range states {
+		if allowed.Contains(state) == false {
+			return nil, fmt.Errorf("Invalid %[1]s \"%[2]s\". Must be one of %[3]s", name, state, strings.Join(allowed, ", "))
+		}
+	}
+
+	return states, nil
+}
+
+// ReviewStates list of overall review states for a review rule. In YAML,
+// the value may be given as a single string, or a list of strings.
+type ReviewStates StringList
+
+// UnmarshalYAML custom parser to validate review states in YAML
+func (l *ReviewStates) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	states, err := unmarshalStates(unmarshal, "review state", StringList{"approved", "changes-requested", "commented", "none"})
+	if err != nil {
+		return err
+	}
+
+	*l = ReviewStates(states)
+	return nil
+}
+
+// Contains checks if a state is an exact match of any state in the list
+func (l ReviewStates) Contains(s string) bool {
+	return StringList(l).Contains(s)
+}
+
+// ChecksStates list of overall commit status and check run states for a checks
+// rule. In YAML, the value may be given as a single string, or a list of strings.
+type ChecksStates StringList
+
+// UnmarshalYAML custom parser to validate checks states in YAML
+func (l *ChecksStates) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	states, err := unmarshalStates(unmarshal, "checks state", StringList{"failure", "pending", "success", "none"})
+	if err != nil {
+		return err
+	}
+
+	*l = ChecksStates(states)
+	return nil
+}
+
+// Contains checks if a state is an exact match of any state in the list
+func (l ChecksStates) Contains(s string) bool {
+	return StringList(l).Contains(s)
+}
+
+// FileStatuses list of changed file statuses for a file rule. In YAML, the
+// value may be given as a single string, or a list of strings.
+type FileStatuses StringList
+
+// UnmarshalYAML custom parser to validate file statuses in YAML
+func (l *FileStatuses) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	statuses, err := unmarshalStates(unmarshal, "file status", StringList{"added", "removed", "modified", "renamed", "copied", "changed", "unchanged"})
+	if err != nil {
+		return err
+	}
+
+	*l = FileStatuses(statuses)
+	return nil
+}
+
+// Contains checks if a status is an exact match of any status in the list
+func (l FileStatuses) Contains(s string) bool {
+	return StringList(l).Contains(s)
+}
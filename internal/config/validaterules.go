@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Returns the match and no-match patterns of a string rule
+func (r RuleTypeString) patterns() StringList {
+	patterns := StringList{}
+	patterns = append(patterns, r.Match...)
+	return append(patterns, r.NoMatch...)
+}
+
+// Returns every regex pattern in a rule set, including nested condition blocks
+func (r YamlRuleSet) patterns() StringList {
+	patterns := StringList{}
+	stringRules := []RuleTypeString{
+		r.Head,
+		r.Base,
+		r.Title,
+		r.Body,
+		r.User,
+		r.File.RuleTypeString,
+		r.Labels.RuleTypeString,
+		r.Reviewer.RuleTypeString,
+		r.Assignee.RuleTypeString,
+		r.Commit.Message,
+		r.Commit.Author,
+		r.Comment.Text,
+		r.Comment.Author,
+		r.Milestone.RuleTypeString,
+		r.Association,
+		r.CodeOwners.RuleTypeString,
+		r.Issue.Labels.RuleTypeString,
+	}
+	for _, stringRule := range stringRules {
+		patterns = append(patterns, stringRule.patterns()...)
+	}
+	patterns = append(patterns, r.Number.Match...)
+	patterns = append(patterns, r.Number.NoMatch...)
+
+	for _, allSet := range r.All {
+		patterns = append(patterns, allSet.patterns()...)
+	}
+
+	for _, anySet := range r.Any {
+		patterns = append(patterns, anySet.patterns()...)
+	}
+
+	if r.Not != nil {
+		patterns = append(patterns, r.Not.patterns()...)
+	}
+
+	return patterns
+}
+
+// Returns every glob pattern in a rule set, including nested condition blocks
+func (r YamlRuleSet) globs() StringList {
+	globs := StringList{}
+	globs = append(globs, r.File.Glob...)
+	globs = append(globs, r.File.NoGlob...)
+	globs = append(globs, r.Size.Exclude...)
+
+	for _, allSet := range r.All {
+		globs = append(globs, allSet.globs()...)
+	}
+
+	for _, anySet := range r.Any {
+		globs = append(globs, anySet.globs()...)
+	}
+
+	if r.Not != nil {
+		globs = append(globs, r.Not.globs()...)
+	}
+
+	return globs
+}
+
+// Checks each segment of a glob pattern. Segments are matched separately,
+// so an invalid segment would otherwise only fail for some file paths
+func validateGlob(glob string) error {
+	for _, segment := range strings.Split(glob, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validates the quantifier and min-count options of a rule. The none quantifier
+// does not support min-count, and both options require checks to count against
+func validateQuantifier(ruleName string, quantifier Quantifier, minCount int, checks string, hasChecks bool) error {
+	if quantifier == QuantifierNone && minCount > 0 {
+		return fmt.Errorf("%[1]s min-count can not be used with the none quantifier", ruleName)
+	}
+
+	if (quantifier != "" || minCount > 0) && hasChecks == false {
+		return fmt.Errorf("%[1]s quantifier and min-count require %[2]s", ruleName, checks)
+	}
+
+	return nil
+}
+
+// Validates the quantifier options of a rule set, including nested condition blocks
+func (r YamlRuleSet) validateQuantifiers() error {
+	fileChecks := len(r.File.Exact) > 0 || len(r.File.Match) > 0 || len(r.File.Glob) > 0
+	if err := validateQuantifier("file-rule", r.File.Quantifier, r.File.MinCount, "an exact, match or glob check", fileChecks); err != nil {
+		return err
+	}
+
+	if err := validateQuantifier("commit-rule", r.Commit.Quantifier, r.Commit.MinCount, "a message or author check", r.Commit.IsEmpty() == false); err != nil {
+		return err
+	}
+
+	if err := validateQuantifier("comment-rule", r.Comment.Quantifier, r.Comment.MinCount, "a text, author, latest or maintainers check", r.Comment.IsEmpty() == false); err != nil {
+		return err
+	}
+
+	for _, allSet := range r.All {
+		if err := allSet.validateQuantifiers(); err != nil {
+			return err
+		}
+	}
+
+	for _, anySet := range r.Any {
+		if err := anySet.validateQuantifiers(); err != nil {
+			return err
+		}
+	}
+
+	if r.Not != nil {
+		return r.Not.validateQuantifiers()
+	}
+
+	return nil
+}
+
+// Validates the rules, so invalid rules are reported once when the config
+// is loaded instead of for every pull request. Every invalid pattern is reported
+func validateRules(rules []YamlRuleGroup) error {
+	invalid := []string{}
+	for _, rule := range rules {
+		patterns := rule.YamlRuleSet.patterns()
+		patterns = append(patterns, rule.InheritLabels.patterns()...)
+
+		for _, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				invalid = append(invalid, fmt.Sprintf("Invalid pattern \"%[1]s\" for label \"%[2]s\": %[3]s", pattern, rule.Label, err))
+			}
+		}
+
+		for _, glob := range rule.YamlRuleSet.globs() {
+			if err := validateGlob(glob); err != nil {
+				invalid = append(invalid, fmt.Sprintf("Invalid glob \"%[1]s\" for label \"%[2]s\": %[3]s", glob, rule.Label, err))
+			}
+		}
+	}
+
+	if len(invalid) > 0 {
+		return errors.New(strings.Join(invalid, "\n"))
+	}
+
+	for _, rule := range rules {
+		if rule.InheritLabels.IsEmpty() == false && rule.RemoveWhenUnmatched == true {
+			return errors.New("Invalid rule: remove-when-unmatched can not be used with inherit-labels, as labels are only copied")
+		}
+
+		if err := rule.YamlRuleSet.validateQuantifiers(); err != nil {
+			return fmt.Errorf("Invalid rule for label \"%[1]s\": %[2]w", rule.Label, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+// StringList list of string values for a rule check. In YAML, the value
+// may be given as a single string, or a list of strings.
+type StringList []string
+
+// UnmarshalYAML custom parser for a string or list of strings in YAML.
+// Empty strings are ignored
+func (l *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []string
+
+	if err := unmarshal(&values); err != nil {
+		var value string
+
+		if err := unmarshal(&value); err != nil {
+			return err
+		}
+
+		values = []string{value}
+	}
+
+	list := StringList{}
+	for _, value := range values {
+		if value != "" {
+			list = append(list, value)
+		}
+	}
+
+	*l = list
+	return nil
+}
+
+// Contains checks if a value is an exact match of any value in the list
+func (l StringList) Contains(s string) bool {
+	for _, value := range l {
+		if value == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IntList list of integer values for a rule check. In YAML, the value
+// may be given as a single integer, or a list of integers.
+type IntList []int
+
+// UnmarshalYAML custom parser for an integer or list of integers in YAML.
+// Zero values are ignored
+func (l *IntList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []int
+
+	if err := unmarshal(&values); err != nil {
+		var value int
+
+		if err := unmarshal(&value); err != nil {
+			return err
+		}
+
+		values = []int{value}
+	}
+
+	list := IntList{}
+	for _, value := range values {
+		if value != 0 {
+			list = append(list, value)
+		}
+	}
+
+	*l = list
+	return nil
+}
+
+// Contains checks if a value is an exact match of any value in the list
+func (l IntList) Contains(i int) bool {
+	for _, value := range l {
+		if value == i {
+			return true
+		}
+	}
+
+	return false
+}
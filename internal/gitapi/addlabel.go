@@ -2,36 +2,61 @@ package gitapi
 
 import (
 	"encoding/json"
-	"fmt"
-	"strings"
-
-	"github.com/tanmancan/label-it/v1/internal/common"
+	"errors"
+	"net/url"
 )
 
 // PrLabel interface describing a pull request and
-// a list of labels to add to the pull request
+// a list of labels to add to, or remove from the pull request
 type PrLabel struct {
 	Issue  int
 	Labels []string
+	Remove []string
 }
 
 // AddLabels adds given list of labels to a specific pull request
-// https://docs.github.com/en/rest/reference/issues#set-labels-for-an-issue
-func AddLabels(prLabel PrLabel, c chan string) {
+// https://docs.github.com/en/rest/reference/issues#add-labels-to-an-issue
+func AddLabels(prLabel PrLabel) error {
+	if len(prLabel.Labels) == 0 {
+		return nil
+	}
+
 	endpoint := buildEndpoint(githubConfig.Endpoints.AddLabels, prLabel.Issue)
 
 	reqBody, err := json.Marshal(map[string][]string{
 		"labels": prLabel.Labels,
 	})
-	common.CheckErr(err)
+	if err != nil {
+		return err
+	}
+
+	request, err := buildRequest("POST", endpoint, reqBody, nil)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = gitClient(request)
+
+	return err
+}
+
+// RemoveLabels removes given list of labels from a specific pull request.
+// Labels that have already been removed from the pull request are ignored
+// https://docs.github.com/en/rest/reference/issues#remove-a-label-from-an-issue
+func RemoveLabels(prLabel PrLabel) error {
+	for _, label := range prLabel.Remove {
+		endpoint := buildEndpoint(githubConfig.Endpoints.RemoveLabel, prLabel.Issue, url.PathEscape(label))
+
+		request, err := buildRequest("DELETE", endpoint, nil, nil)
+		if err != nil {
+			return err
+		}
 
-	request := buildRequest("POST", endpoint, reqBody, nil)
-	gitClient(request)
+		_, _, err = gitClient(request)
+		if err != nil && errors.Is(err, ErrNotFound) == false {
+			return err
+		}
+	}
 
-	log := fmt.Sprintf(
-		"Added label(s) \"%[1]s\" to PR #%[2]d",
-		strings.Join(prLabel.Labels, ", "),
-		prLabel.Issue,
-	)
-	c <- log
+	return nil
 }
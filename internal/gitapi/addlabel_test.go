@@ -0,0 +1,65 @@
+package gitapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestRemoveLabels(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	var removed []string
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("RemoveLabels() method = %v, want DELETE", r.Method)
+		}
+		removed = append(removed, r.URL.EscapedPath())
+		switch r.URL.Path {
+		case "/repos/world/Robot/issues/7/labels/already-removed":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Label does not exist"}`)
+		case "/repos/world/Robot/issues/7/labels/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"Must have admin rights to Repository."}`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	t.Run("removes escaped labels and ignores missing labels", func(t *testing.T) {
+		removed = nil
+		err := RemoveLabels(PrLabel{Issue: 7, Remove: []string{"size/XL", "already-removed", "frontend"}})
+		if err != nil {
+			t.Fatalf("RemoveLabels() error = %v", err)
+		}
+		want := []string{
+			"/repos/world/Robot/issues/7/labels/size%2FXL",
+			"/repos/world/Robot/issues/7/labels/already-removed",
+			"/repos/world/Robot/issues/7/labels/frontend",
+		}
+		if fmt.Sprint(removed) != fmt.Sprint(want) {
+			t.Errorf("RemoveLabels() requests = %v, want %v", removed, want)
+		}
+	})
+
+	t.Run("returns api errors", func(t *testing.T) {
+		err := RemoveLabels(PrLabel{Issue: 7, Remove: []string{"forbidden"}})
+		if !errors.Is(err, ErrForbidden) {
+			t.Errorf("RemoveLabels() error = %v, want %v", err, ErrForbidden)
+		}
+	})
+
+	t.Run("does not add labels when none are given", func(t *testing.T) {
+		removed = nil
+		if err := AddLabels(PrLabel{Issue: 7, Remove: []string{"frontend"}}); err != nil {
+			t.Fatalf("AddLabels() error = %v", err)
+		}
+		if len(removed) != 0 {
+			t.Errorf("AddLabels() should not make a request without labels")
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package gitapi
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+// Installation access tokens are refreshed when they are this close to expiring
+const tokenRefreshWindow = 5 * time.Minute
+
+// Installation access token response for a Github App
+// https://docs.github.com/en/rest/reference/apps#create-an-installation-access-token-for-an-app
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Caches the installation access token for the duration of a run, and
+// transparently requests a new one when it is about to expire
+type appTokenSource struct {
+	mu    sync.Mutex
+	token installationToken
+	now   func() time.Time
+}
+
+var appTokens = &appTokenSource{now: time.Now}
+
+// Parses a PKCS1 or PKCS8 encoded RSA private key
+func parsePrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("No PEM data found in access private-key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Access private-key is not an RSA private key")
+	}
+
+	return rsaKey, nil
+}
+
+// Creates a JSON Web Token signed with the Github App private key.
+// Tokens are valid for 9 minutes and backdated by 60 seconds to allow for clock drift
+// https://docs.github.com/en/developers/apps/authenticating-with-github-apps#authenticating-as-a-github-app
+func buildAppJWT(appID string, key *rsa.PrivateKey, now time.Time) (string, error) {
+	var issuer interface{} = appID
+	if id, err := strconv.ParseInt(appID, 10, 64); err == nil {
+		issuer = id
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-60 * time.Second).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": issuer,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := fmt.Sprintf("%[1]s.%[2]s", encoding.EncodeToString(header), encoding.EncodeToString(claims))
+
+	hashed := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%[1]s.%[2]s", unsigned, encoding.EncodeToString(signature)), nil
+}
+
+// Exchanges a Github App JWT for an installation access token
+func requestInstallationToken(access config.YamlGithubAccess, now time.Time) (installationToken, error) {
+	token := installationToken{}
+
+	pemBytes, err := ioutil.ReadFile(access.PrivateKey)
+	if err != nil {
+		return token, err
+	}
+
+	key, err := parsePrivateKey(pemBytes)
+	if err != nil {
+		return token, err
+	}
+
+	jwt, err := buildAppJWT(access.AppID, key, now)
+	if err != nil {
+		return token, err
+	}
+
+	endpoint := formatEndpoint(githubConfig.Endpoints.AppAccessToken, access.InstallationID)
+	request, err := buildRequestWithAuth("POST", endpoint, nil, nil, fmt.Sprintf("Bearer %[1]s", jwt))
+	if err != nil {
+		return token, err
+	}
+
+	parsedResponse, _, err := gitClient(request)
+	if err != nil {
+		return token, err
+	}
+
+	err = json.Unmarshal(parsedResponse, &token)
+
+	return token, err
+}
+
+// Returns a cached installation access token, requesting a new
+// token if none exists or the current token is about to expire
+func (s *appTokenSource) Token(access config.YamlGithubAccess) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token.Token != "" && now.Add(tokenRefreshWindow).Before(s.token.ExpiresAt) {
+		return s.token.Token, nil
+	}
+
+	token, err := requestInstallationToken(access, now)
+	if err != nil {
+		return "", fmt.Errorf("Unable to create Github App installation token: %[1]w", err)
+	}
+	s.token = token
+
+	return s.token.Token, nil
+}
@@ -0,0 +1,151 @@
+package gitapi
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+// Writes a new RSA private key to a temporary PEM file
+func writeTestPrivateKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, err := ioutil.TempFile("", "label-it-app-*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pem.Encode(keyFile, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	keyFile.Close()
+	t.Cleanup(func() {
+		os.Remove(keyFile.Name())
+	})
+	return key, keyFile.Name()
+}
+
+// Verifies the signature of a JWT and returns its claims
+func verifyTestJWT(t *testing.T, jwt string, key *rsa.PublicKey) map[string]interface{} {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT should have 3 parts, found %d", len(parts))
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	hashed := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature); err != nil {
+		t.Errorf("JWT signature is invalid: %v", err)
+	}
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	claims := map[string]interface{}{}
+	json.Unmarshal(claimsJSON, &claims)
+	return claims
+}
+
+func Test_buildAppJWT(t *testing.T) {
+	key, _ := writeTestPrivateKey(t)
+	now := time.Unix(1600000000, 0)
+
+	jwt, err := buildAppJWT("1234", key, now)
+	if err != nil {
+		t.Fatalf("buildAppJWT() error = %v", err)
+	}
+
+	claims := verifyTestJWT(t, jwt, &key.PublicKey)
+	want := map[string]float64{
+		"iss": 1234,
+		"iat": float64(now.Unix() - 60),
+		"exp": float64(now.Unix() + 540),
+	}
+	for claim, val := range want {
+		if claims[claim] != val {
+			t.Errorf("JWT claim %s = %v, want %v", claim, claims[claim], val)
+		}
+	}
+}
+
+func Test_parsePrivateKey(t *testing.T) {
+	key, _ := writeTestPrivateKey(t)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	tests := []struct {
+		name    string
+		pem     []byte
+		wantErr bool
+	}{
+		{"parses pkcs1 key", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), false},
+		{"parses pkcs8 key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), false},
+		{"rejects non pem data", []byte("not a key"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrivateKey(tt.pem)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.N.Cmp(key.N) != 0 {
+				t.Errorf("parsePrivateKey() returned a different key")
+			}
+		})
+	}
+}
+
+func Test_appTokenSource_Token(t *testing.T) {
+	key, keyPath := writeTestPrivateKey(t)
+	now := time.Unix(1600000000, 0)
+	calls := 0
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != "POST" || r.URL.Path != "/app/installations/5678/access_tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		verifyTestJWT(t, jwt, &key.PublicKey)
+		fmt.Fprintf(w, `{"token":"ghs_token%[1]d","expires_at":"%[2]s"}`, calls, now.Add(time.Hour).Format(time.RFC3339))
+	})
+
+	access := config.YamlGithubAccess{
+		AppID:          "1234",
+		InstallationID: "5678",
+		PrivateKey:     keyPath,
+	}
+	source := &appTokenSource{now: func() time.Time { return now }}
+
+	tests := []struct {
+		name      string
+		advance   time.Duration
+		want      string
+		wantCalls int
+	}{
+		{"requests a new token", 0, "ghs_token1", 1},
+		{"reuses cached token", 30 * time.Minute, "ghs_token1", 1},
+		{"refreshes token before expiry", 26 * time.Minute, "ghs_token2", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			got, err := source.Token(access)
+			if err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Token() = %v, want %v", got, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Token() requests = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// CheckPending conclusion of a status or check run that has not completed
+const CheckPending = "pending"
+
+// PrCheck name and conclusion of a commit status or check run. Statuses
+// use their state as the conclusion, ex: success, failure, error or pending.
+// Check runs use their conclusion, ex: success, failure, neutral, cancelled,
+// skipped, timed_out or action_required. Check runs that are not completed
+// use the pending conclusion
+type PrCheck struct {
+	Name       string
+	Conclusion string
+}
+
+// CommitStatus properties describing a commit status
+type CommitStatus struct {
+	Context string `json:"context"`
+	State   string `json:"state"`
+}
+
+// CombinedStatusResponse interface used to unmarshal JSON response
+type CombinedStatusResponse struct {
+	State    string         `json:"state"`
+	Statuses []CommitStatus `json:"statuses"`
+}
+
+// CheckRun properties describing a check run
+type CheckRun struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// ListCheckRunsResponse interface used to unmarshal JSON response
+type ListCheckRunsResponse struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+// ListStatuses get the commit statuses for a commit SHA
+// https://docs.github.com/en/rest/reference/repos#get-the-combined-status-for-a-specific-reference
+func ListStatuses(sha string) ([]CommitStatus, error) {
+	endpoint := buildEndpoint(githubConfig.Endpoints.CombinedStatus, url.PathEscape(sha))
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	statuses := []CommitStatus{}
+	err := fetchAllPages(endpoint, query, func(page []byte) error {
+		combined := CombinedStatusResponse{}
+		if err := json.Unmarshal(page, &combined); err != nil {
+			return err
+		}
+		statuses = append(statuses, combined.Statuses...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// ListCheckRuns get the check runs for a commit SHA
+// https://docs.github.com/en/rest/reference/checks#list-check-runs-for-a-git-reference
+func ListCheckRuns(sha string) ([]CheckRun, error) {
+	endpoint := buildEndpoint(githubConfig.Endpoints.ListCheckRuns, url.PathEscape(sha))
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	checkRuns := []CheckRun{}
+	err := fetchAllPages(endpoint, query, func(page []byte) error {
+		runs := ListCheckRunsResponse{}
+		if err := json.Unmarshal(page, &runs); err != nil {
+			return err
+		}
+		checkRuns = append(checkRuns, runs.CheckRuns...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return checkRuns, nil
+}
+
+// ListChecks get the commit statuses and check runs for a commit SHA
+func ListChecks(sha string) ([]PrCheck, error) {
+	statuses, err := ListStatuses(sha)
+	if err != nil {
+		return nil, err
+	}
+
+	checkRuns, err := ListCheckRuns(sha)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []PrCheck{}
+	for _, status := range statuses {
+		checks = append(checks, PrCheck{Name: status.Context, Conclusion: status.State})
+	}
+
+	for _, run := range checkRuns {
+		conclusion := run.Conclusion
+		if run.Status != "completed" || conclusion == "" {
+			conclusion = CheckPending
+		}
+		checks = append(checks, PrCheck{Name: run.Name, Conclusion: conclusion})
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,43 @@
+package gitapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestListChecks(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/world/Robot/commits/abc123/status":
+			fmt.Fprint(w, `{"state":"failure","statuses":[{"context":"ci/lint","state":"success"},{"context":"ci/deploy","state":"error"}]}`)
+		case "/repos/world/Robot/commits/abc123/check-runs":
+			fmt.Fprint(w, `{"total_count":2,"check_runs":[
+				{"name":"build","status":"completed","conclusion":"success"},
+				{"name":"test","status":"in_progress","conclusion":null}
+			]}`)
+		default:
+			t.Errorf("ListChecks() unexpected path %v", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	checks, err := ListChecks("abc123")
+	if err != nil {
+		t.Fatalf("ListChecks() error = %v", err)
+	}
+	want := []PrCheck{
+		{Name: "ci/lint", Conclusion: "success"},
+		{Name: "ci/deploy", Conclusion: "error"},
+		{Name: "build", Conclusion: "success"},
+		{Name: "test", Conclusion: CheckPending},
+	}
+	if !reflect.DeepEqual(checks, want) {
+		t.Errorf("ListChecks() = %v, want %v", checks, want)
+	}
+}
@@ -0,0 +1,75 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// PrComment properties describing an issue or review comment on a pull request
+type PrComment struct {
+	ID          int    `json:"id"`
+	User        PrUser `json:"user"`
+	Body        string `json:"body"`
+	Association string `json:"author_association"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ListCommentsResponse A list of comments from the list comments endpoints
+type ListCommentsResponse []PrComment
+
+// Fetches every page of comments from an endpoint
+func listAllComments(endpoint string) (ListCommentsResponse, error) {
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	comments := ListCommentsResponse{}
+	err := fetchAllPages(endpoint, query, func(page []byte) error {
+		commentPage := ListCommentsResponse{}
+		if err := json.Unmarshal(page, &commentPage); err != nil {
+			return err
+		}
+		comments = append(comments, commentPage...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// ListIssueComments get all comments on the conversation of a pull request
+// https://docs.github.com/en/rest/reference/issues#list-issue-comments
+func ListIssueComments(number int) (ListCommentsResponse, error) {
+	return listAllComments(buildEndpoint(githubConfig.Endpoints.ListComments, number))
+}
+
+// ListReviewComments get all review comments on the diff of a pull request
+// https://docs.github.com/en/rest/reference/pulls#list-review-comments-on-a-pull-request
+func ListReviewComments(number int) (ListCommentsResponse, error) {
+	return listAllComments(buildEndpoint(githubConfig.Endpoints.ListPrComments, number))
+}
+
+// ListComments get all issue and review comments for a pull request,
+// in chronological order
+func ListComments(number int) (ListCommentsResponse, error) {
+	comments, err := ListIssueComments(number)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewComments, err := ListReviewComments(number)
+	if err != nil {
+		return nil, err
+	}
+	comments = append(comments, reviewComments...)
+
+	// Timestamps use the ISO 8601 format, so they sort as strings
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt < comments[j].CreatedAt
+	})
+
+	return comments, nil
+}
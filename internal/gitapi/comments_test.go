@@ -0,0 +1,42 @@
+package gitapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestListComments(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/world/Robot/issues/9/comments":
+			fmt.Fprint(w, `[
+				{"id":1,"user":{"login":"octocat"},"body":"/label needs-qa","author_association":"MEMBER","created_at":"2021-03-01T10:00:00Z"},
+				{"id":3,"user":{"login":"hubot"},"body":"LGTM","author_association":"NONE","created_at":"2021-03-03T10:00:00Z"}
+			]`)
+		case "/repos/world/Robot/pulls/9/comments":
+			fmt.Fprint(w, `[{"id":2,"user":{"login":"monalisa"},"body":"Typo here","author_association":"OWNER","created_at":"2021-03-02T10:00:00Z"}]`)
+		default:
+			t.Errorf("ListComments() unexpected path = %v", r.URL.Path)
+		}
+	})
+
+	comments, err := ListComments(9)
+	if err != nil {
+		t.Fatalf("ListComments() error = %v", err)
+	}
+	ids := []int{}
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("ListComments() ids = %v, want [1 2 3] in chronological order", ids)
+	}
+	if comments[1].Body != "Typo here" || comments[1].Association != "OWNER" || comments[1].User.Login != "monalisa" {
+		t.Errorf("ListComments() review comment = %+v", comments[1])
+	}
+}
@@ -0,0 +1,65 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// PrCommitAuthor git author of a commit
+type PrCommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// PrCommitDetails git details of a commit
+type PrCommitDetails struct {
+	Message string         `json:"message"`
+	Author  PrCommitAuthor `json:"author"`
+}
+
+// PrCommit properties describing a commit in a pull request. Author is
+// nil if the commit is not linked to a Github user
+type PrCommit struct {
+	SHA    string          `json:"sha"`
+	Commit PrCommitDetails `json:"commit"`
+	Author *PrUser         `json:"author"`
+}
+
+// AuthorLogin returns the Github username of the commit author, or
+// the git author name if the commit is not linked to a Github user
+func (c PrCommit) AuthorLogin() string {
+	if c.Author != nil && c.Author.Login != "" {
+		return c.Author.Login
+	}
+
+	return c.Commit.Author.Name
+}
+
+// ListCommitsResponse A list of commits from the list pull request commits endpoint
+type ListCommitsResponse []PrCommit
+
+// ListCommits get the commits for a given pull request number. Follows
+// the Link header until all pages are fetched. Github returns a maximum
+// of 250 commits for a pull request
+// https://docs.github.com/en/rest/reference/pulls#list-commits-on-a-pull-request
+func ListCommits(number int) (ListCommitsResponse, error) {
+	endpoint := buildEndpoint(githubConfig.Endpoints.ListCommits, number)
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	commits := ListCommitsResponse{}
+	err := fetchAllPages(endpoint, query, func(page []byte) error {
+		commitPage := ListCommitsResponse{}
+		if err := json.Unmarshal(page, &commitPage); err != nil {
+			return err
+		}
+		commits = append(commits, commitPage...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
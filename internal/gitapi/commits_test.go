@@ -0,0 +1,42 @@
+package gitapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestListCommits(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/world/Robot/pulls/9/commits" {
+			t.Errorf("ListCommits() path = %v, want /repos/world/Robot/pulls/9/commits", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"sha":"c3","commit":{"message":"fix: typo","author":{"name":"Mona Lisa"}},"author":null}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%[1]s%[2]s?per_page=100&page=2>; rel="next"`, r.Host, r.URL.Path))
+		fmt.Fprint(w, `[
+			{"sha":"c1","commit":{"message":"feat!: new api","author":{"name":"Octo Cat"}},"author":{"login":"octocat"}},
+			{"sha":"c2","commit":{"message":"chore: deps","author":{"name":"Hubot"}},"author":{"login":"hubot"}}
+		]`)
+	})
+
+	commits, err := ListCommits(9)
+	if err != nil {
+		t.Fatalf("ListCommits() error = %v", err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("ListCommits() returned %d commits, want 3", len(commits))
+	}
+	if commits[0].Commit.Message != "feat!: new api" || commits[0].AuthorLogin() != "octocat" {
+		t.Errorf("ListCommits() first commit = %+v", commits[0])
+	}
+	if commits[2].AuthorLogin() != "Mona Lisa" {
+		t.Errorf("PrCommit.AuthorLogin() = %v, want git author name for unlinked commits", commits[2].AuthorLogin())
+	}
+}
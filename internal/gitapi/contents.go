@@ -0,0 +1,73 @@
+package gitapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Contents of a file in the repository
+type fileContents struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GetFileContents get the contents of a file in the repository at the given
+// branch, tag or commit. If ref is empty, the default branch is used
+// https://docs.github.com/en/rest/reference/repos#get-repository-content
+func GetFileContents(filePath string, ref string) ([]byte, error) {
+	var escaped []string
+	for _, part := range strings.Split(strings.Trim(filePath, "/"), "/") {
+		escaped = append(escaped, url.PathEscape(part))
+	}
+
+	endpoint := buildEndpoint(githubConfig.Endpoints.GetContents, strings.Join(escaped, "/"))
+	query := map[string]string{}
+	if ref != "" {
+		query["ref"] = ref
+	}
+
+	request, err := buildRequest("GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedResponse, _, err := gitClient(request)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := fileContents{}
+	if err := json.Unmarshal(parsedResponse, &contents); err != nil {
+		return nil, err
+	}
+
+	if contents.Type != "file" || contents.Encoding != "base64" {
+		return nil, fmt.Errorf("Unable to read \"%[1]s\". Path is not a file", filePath)
+	}
+
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(contents.Content, "\n", ""))
+}
+
+// Locations Github searches for a CODEOWNERS file, in order
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// GetCodeOwners get the contents of the CODEOWNERS file at the given branch, tag or commit.
+// The file is searched for in the same locations as Github
+// https://docs.github.com/en/github/creating-cloning-and-archiving-repositories/about-code-owners#codeowners-file-location
+func GetCodeOwners(ref string) ([]byte, error) {
+	for _, filePath := range codeOwnersPaths {
+		contents, err := GetFileContents(filePath, ref)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+
+		return contents, err
+	}
+
+	return nil, fmt.Errorf("No CODEOWNERS file found at \"%[1]s\"", ref)
+}
@@ -0,0 +1,46 @@
+package gitapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestGetCodeOwners(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	var paths []string
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Query().Get("ref") != "main" {
+			t.Errorf("GetCodeOwners() ref = %v, want main", r.URL.Query().Get("ref"))
+		}
+		if r.URL.Path != "/repos/world/Robot/contents/CODEOWNERS" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		content := base64.StdEncoding.EncodeToString([]byte("* @octo-org/core\n"))
+		fmt.Fprintf(w, `{"type":"file","encoding":"base64","content":"%[1]s\n"}`, content)
+	})
+
+	contents, err := GetCodeOwners("main")
+	if err != nil {
+		t.Fatalf("GetCodeOwners() error = %v", err)
+	}
+	if string(contents) != "* @octo-org/core\n" {
+		t.Errorf("GetCodeOwners() = %q", contents)
+	}
+	want := "[/repos/world/Robot/contents/.github/CODEOWNERS /repos/world/Robot/contents/CODEOWNERS]"
+	if fmt.Sprint(paths) != want {
+		t.Errorf("GetCodeOwners() requests = %v, want %v", paths, want)
+	}
+
+	config.YamlConfig.Repo = "Missing"
+	if _, err := GetCodeOwners("main"); err == nil {
+		t.Errorf("GetCodeOwners() should return an error when no CODEOWNERS file is found")
+	}
+}
@@ -0,0 +1,106 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Kinds of errors returned by the Github API. Use errors.Is to check
+// the kind of an APIError
+var (
+	ErrUnauthorized = errors.New("authentication failed")
+	ErrForbidden    = errors.New("access forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limit exceeded")
+)
+
+// APIFieldError describes an individual field error in a validation failure response
+// https://docs.github.com/en/rest/overview/resources-in-the-rest-api#client-errors
+type APIFieldError struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// APIError error response returned by the Github API
+type APIError struct {
+	Method           string
+	URL              string
+	StatusCode       int
+	Message          string          `json:"message"`
+	DocumentationURL string          `json:"documentation_url"`
+	Errors           []APIFieldError `json:"errors"`
+	Kind             error
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+
+	details := []string{}
+	for _, fieldErr := range e.Errors {
+		switch {
+		case fieldErr.Message != "":
+			details = append(details, fieldErr.Message)
+		case fieldErr.Field != "":
+			details = append(details, fmt.Sprintf("%[1]s %[2]s %[3]s", fieldErr.Resource, fieldErr.Field, fieldErr.Code))
+		}
+	}
+	if len(details) > 0 {
+		message = fmt.Sprintf("%[1]s: %[2]s", message, strings.Join(details, ", "))
+	}
+
+	errMessage := fmt.Sprintf("%[1]s %[2]s: %[3]d %[4]s", e.Method, e.URL, e.StatusCode, message)
+	if e.DocumentationURL != "" {
+		errMessage = fmt.Sprintf("%[1]s (%[2]s)", errMessage, e.DocumentationURL)
+	}
+
+	return errMessage
+}
+
+// Unwrap returns the kind of error, allowing errors.Is checks
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// Determines the kind of error for a given status code and error response
+func apiErrorKind(statusCode int, header http.Header, message string) error {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		if header.Get("X-RateLimit-Remaining") == "0" || strings.Contains(strings.ToLower(message), "rate limit") {
+			return ErrRateLimited
+		}
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+
+	return nil
+}
+
+// Creates an APIError from an unsuccessful response
+func newAPIError(request *http.Request, res *http.Response, content []byte) *APIError {
+	apiErr := &APIError{}
+	json.Unmarshal(content, apiErr)
+
+	apiErr.Method = request.Method
+	apiErr.URL = request.URL.Path
+	apiErr.StatusCode = res.StatusCode
+	apiErr.Kind = apiErrorKind(res.StatusCode, res.Header, apiErr.Message)
+
+	return apiErr
+}
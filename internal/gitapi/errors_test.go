@@ -0,0 +1,104 @@
+package gitapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func Test_gitClient_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		headers     map[string]string
+		body        string
+		wantKind    error
+		wantMessage string
+	}{
+		{
+			"bad credentials",
+			401,
+			nil,
+			`{"message":"Bad credentials","documentation_url":"https://docs.github.com/rest"}`,
+			ErrUnauthorized,
+			"GET /repos/a/b/pulls: 401 Bad credentials (https://docs.github.com/rest)",
+		},
+		{
+			"missing permissions",
+			403,
+			nil,
+			`{"message":"Resource not accessible by integration"}`,
+			ErrForbidden,
+			"GET /repos/a/b/pulls: 403 Resource not accessible by integration",
+		},
+		{
+			"rate limit exceeded",
+			403,
+			map[string]string{"X-RateLimit-Remaining": "0"},
+			`{"message":"API rate limit exceeded for user ID 1."}`,
+			ErrRateLimited,
+			"GET /repos/a/b/pulls: 403 API rate limit exceeded for user ID 1.",
+		},
+		{
+			"repository not found",
+			404,
+			nil,
+			`{"message":"Not Found","documentation_url":"https://docs.github.com/rest/reference/pulls#list-pull-requests"}`,
+			ErrNotFound,
+			"GET /repos/a/b/pulls: 404 Not Found (https://docs.github.com/rest/reference/pulls#list-pull-requests)",
+		},
+		{
+			"validation failed",
+			422,
+			nil,
+			`{"message":"Validation Failed","errors":[{"resource":"Label","field":"name","code":"invalid"}]}`,
+			ErrValidation,
+			"GET /repos/a/b/pulls: 422 Validation Failed: Label name invalid",
+		},
+		{
+			"empty error body",
+			400,
+			nil,
+			"",
+			nil,
+			"GET /repos/a/b/pulls: 400 Bad Request",
+		},
+	}
+	maxRetries := defaultTransport.maxRetries
+	defaultTransport.maxRetries = 0
+	t.Cleanup(func() {
+		defaultTransport.maxRetries = maxRetries
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				for key, val := range tt.headers {
+					w.Header().Set(key, val)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			request, _ := buildRequest("GET", "/repos/a/b/pulls", nil, nil)
+			content, _, err := gitClient(request)
+			if content != nil {
+				t.Errorf("gitClient() content = %s, want nil", content)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("gitClient() error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("APIError.StatusCode = %v, want %v", apiErr.StatusCode, tt.status)
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("gitClient() error kind = %v, want %v", apiErr.Kind, tt.wantKind)
+			}
+			if got := err.Error(); got != tt.wantMessage {
+				t.Errorf("APIError.Error() = %v, want %v", got, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// GetPull get a single pull request. Unlike the list pull requests endpoint,
+// this includes the additions, deletions and changed files counts
+// https://docs.github.com/en/rest/reference/pulls#get-a-pull-request
+func GetPull(number int) (PullRequest, error) {
+	endpoint := buildEndpoint(githubConfig.Endpoints.GetPull, number)
+	pr := PullRequest{}
+
+	request, err := buildRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return pr, err
+	}
+
+	parsedResponse, _, err := gitClient(request)
+	if err != nil {
+		return pr, err
+	}
+
+	if err := json.Unmarshal(parsedResponse, &pr); err != nil {
+		return pr, err
+	}
+
+	return pr, nil
+}
+
+// Number of times to request a pull request while Github computes the mergeable state
+var mergeableAttempts = 3
+
+// Delay between requests while Github computes the mergeable state
+var mergeableDelay = 2 * time.Second
+
+// GetMergeablePull get a single pull request including its mergeable state.
+// Github computes the mergeable state in the background, and returns null
+// until it is ready. The pull request is requested again after a short delay
+// until the mergeable state is known, or the number of attempts is reached
+// https://docs.github.com/en/rest/guides/getting-started-with-the-git-database-api#checking-mergeability-of-pull-requests
+func GetMergeablePull(number int) (PullRequest, error) {
+	pr, err := GetPull(number)
+
+	for attempt := 1; err == nil && pr.Mergeable == nil && attempt < mergeableAttempts; attempt++ {
+		time.Sleep(mergeableDelay)
+		pr, err = GetPull(number)
+	}
+
+	return pr, err
+}
@@ -0,0 +1,73 @@
+package gitapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestGetPull(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/world/Robot/pulls/12" {
+			t.Errorf("GetPull() path = %v, want /repos/world/Robot/pulls/12", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"number":12,"additions":120,"deletions":30,"changed_files":4}`)
+	})
+
+	pr, err := GetPull(12)
+	if err != nil {
+		t.Fatalf("GetPull() error = %v", err)
+	}
+	if pr.Number != 12 || pr.Additions != 120 || pr.Deletions != 30 || pr.ChangedFiles != 4 {
+		t.Errorf("GetPull() = %+v", pr)
+	}
+}
+
+func TestGetMergeablePull(t *testing.T) {
+	requests := 0
+	computedAfter := 0
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if computedAfter == 0 || requests < computedAfter {
+			fmt.Fprint(w, `{"number":12,"mergeable":null,"mergeable_state":"unknown"}`)
+			return
+		}
+		fmt.Fprint(w, `{"number":12,"mergeable":false,"mergeable_state":"dirty"}`)
+	})
+	delay := mergeableDelay
+	mergeableDelay = 0
+	t.Cleanup(func() {
+		mergeableDelay = delay
+	})
+
+	t.Run("requests again until mergeable state is computed", func(t *testing.T) {
+		requests = 0
+		computedAfter = 2
+		pr, err := GetMergeablePull(12)
+		if err != nil {
+			t.Fatalf("GetMergeablePull() error = %v", err)
+		}
+		if requests != 2 {
+			t.Errorf("GetMergeablePull() made %d requests, want 2", requests)
+		}
+		if pr.Mergeable == nil || *pr.Mergeable != false || pr.MergeableState != "dirty" {
+			t.Errorf("GetMergeablePull() = %v %v, want mergeable false and dirty state", pr.Mergeable, pr.MergeableState)
+		}
+	})
+
+	t.Run("stops after max attempts", func(t *testing.T) {
+		requests = 0
+		computedAfter = 0
+		pr, err := GetMergeablePull(12)
+		if err != nil {
+			t.Fatalf("GetMergeablePull() error = %v", err)
+		}
+		if requests != mergeableAttempts || pr.Mergeable != nil {
+			t.Errorf("GetMergeablePull() made %d requests, want %d", requests, mergeableAttempts)
+		}
+	})
+}
@@ -2,21 +2,37 @@ package gitapi
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 
-	"github.com/tanmancan/label-it/v1/internal/common"
 	"github.com/tanmancan/label-it/v1/internal/config"
 )
 
 // Endpoints use by this package
 type githubAPIEndpoints struct {
-	AddLabels   string
-	ListPulls   string
-	ListPrFiles string
+	AddLabels      string
+	RemoveLabel    string
+	ListPulls      string
+	GetPull        string
+	ListPrFiles    string
+	ListReviews    string
+	ListCommits    string
+	TeamMembers    string
+	GetContents    string
+	GetIssue       string
+	ListComments   string
+	ListPrComments string
+	ListTimeline   string
+	CombinedStatus string
+	ListCheckRuns  string
+	AppAccessToken string
 }
 
 // Configuration types for Github API
@@ -35,21 +51,38 @@ var githubConfig = githubAPIConfig{
 		"Content-Type": "application/json",
 	},
 	Endpoints: githubAPIEndpoints{
-		AddLabels:   "/repos/%[1]s/%[2]s/issues/%[3]d/labels",
-		ListPulls:   "/repos/%[1]s/%[2]s/pulls",
-		ListPrFiles: "/repos/%[1]s/%[2]s/pulls/%[3]d/files",
+		AddLabels:      "/repos/%[1]s/%[2]s/issues/%[3]d/labels",
+		RemoveLabel:    "/repos/%[1]s/%[2]s/issues/%[3]d/labels/%[4]s",
+		ListPulls:      "/repos/%[1]s/%[2]s/pulls",
+		GetPull:        "/repos/%[1]s/%[2]s/pulls/%[3]d",
+		ListPrFiles:    "/repos/%[1]s/%[2]s/pulls/%[3]d/files",
+		ListReviews:    "/repos/%[1]s/%[2]s/pulls/%[3]d/reviews",
+		ListCommits:    "/repos/%[1]s/%[2]s/pulls/%[3]d/commits",
+		TeamMembers:    "/orgs/%[1]s/teams/%[2]s/members",
+		GetContents:    "/repos/%[1]s/%[2]s/contents/%[3]s",
+		GetIssue:       "/repos/%[1]s/%[2]s/issues/%[3]d",
+		ListComments:   "/repos/%[1]s/%[2]s/issues/%[3]d/comments",
+		ListPrComments: "/repos/%[1]s/%[2]s/pulls/%[3]d/comments",
+		ListTimeline:   "/repos/%[1]s/%[2]s/issues/%[3]d/timeline",
+		CombinedStatus: "/repos/%[1]s/%[2]s/commits/%[3]s/status",
+		ListCheckRuns:  "/repos/%[1]s/%[2]s/commits/%[3]s/check-runs",
+		AppAccessToken: "/app/installations/%[1]s/access_tokens",
 	},
 }
 
 // Indent and prints a JSON response
-func prettyPrintResponse(content []byte) {
+func prettyPrintResponse(content []byte) error {
 	dst := &bytes.Buffer{}
 	indenterr := json.Indent(dst, content, "", "    ")
-	common.CheckErr(indenterr)
+	if indenterr != nil {
+		return indenterr
+	}
 	fmt.Println(dst.String())
+	return nil
 }
 
-// Populate endpoint templates from githubAPIEndpoints with provided arguments
+// Populate endpoint templates from githubAPIEndpoints with provided arguments.
+// The configured owner and repo are passed before the provided arguments
 func buildEndpoint(endpointTemplate string, args ...interface{}) string {
 	owner := config.YamlConfig.Owner
 	repo := config.YamlConfig.Repo
@@ -61,12 +94,30 @@ func buildEndpoint(endpointTemplate string, args ...interface{}) string {
 		}
 	}
 
-	return fmt.Sprintf(endpointTemplate, argsWithRepo...)
+	return formatEndpoint(endpointTemplate, argsWithRepo...)
+}
+
+// Populate endpoint templates that are not scoped to the configured repository,
+// such as organization or app endpoints, with only the provided arguments
+func formatEndpoint(endpointTemplate string, args ...interface{}) string {
+	return fmt.Sprintf(endpointTemplate, args...)
+}
+
+// Returns the base URL of the Github API. A custom api-url is used as provided,
+// so Github Enterprise Server URLs must include the /api/v3 path
+// https://docs.github.com/en/enterprise-server/rest/overview/resources-in-the-rest-api
+func apiBaseURL() string {
+	apiURL := config.YamlConfig.APIURL
+	if apiURL == "" {
+		return githubConfig.BaseURL
+	}
+
+	return strings.TrimSuffix(apiURL, "/")
 }
 
 // Generate Github API URL for a given endpoint string
 func buildAPIURL(endpoint string) string {
-	return fmt.Sprintf("%[1]s%[2]s", githubConfig.BaseURL, endpoint)
+	return fmt.Sprintf("%[1]s%[2]s", apiBaseURL(), endpoint)
 }
 
 // Generate Basic Authentication token for a request
@@ -76,6 +127,26 @@ func buildBasicAuth() string {
 	return fmt.Sprintf("Basic %s", tokenenc)
 }
 
+// Generate the Authorization header for a request. Github Apps authenticate
+// using an installation access token. Tokens configured with a user use basic
+// authentication, otherwise the token is sent as a bearer token
+func buildAuthHeader() (string, error) {
+	access := config.YamlConfig.Access
+
+	switch {
+	case access.IsApp():
+		token, err := appTokens.Token(access)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Bearer %[1]s", token), nil
+	case access.User != "":
+		return buildBasicAuth(), nil
+	}
+
+	return fmt.Sprintf("Bearer %[1]s", access.Token), nil
+}
+
 // Generate request body
 func buildReqBody(reqBody []byte) *bytes.Buffer {
 	return bytes.NewBuffer(reqBody)
@@ -92,8 +163,16 @@ func buildReqQuery(request *http.Request, reqQueryParam map[string]string) {
 	}
 }
 
-// Builds a API request to be used in http.Client
-func buildRequest(method string, endpoint string, reqBody []byte, reqQueryParam map[string]string) *http.Request {
+// Builds a API request to be used in http.Client. The Authorization header
+// is set by the transport before each attempt, so retried requests use a
+// current Github App installation token
+func buildRequest(method string, endpoint string, reqBody []byte, reqQueryParam map[string]string) (*http.Request, error) {
+	return buildRequestWithAuth(method, endpoint, reqBody, reqQueryParam, "")
+}
+
+// Builds a API request using the given Authorization header. If the header
+// is empty, the configured access credentials are used
+func buildRequestWithAuth(method string, endpoint string, reqBody []byte, reqQueryParam map[string]string, authtoken string) (*http.Request, error) {
 	if method == "" {
 		method = "GET"
 	}
@@ -106,31 +185,99 @@ func buildRequest(method string, endpoint string, reqBody []byte, reqQueryParam
 	body := buildReqBody(reqBody)
 
 	request, reqerr := http.NewRequest(method, url, body)
-	common.CheckErr(reqerr)
+	if reqerr != nil {
+		return nil, reqerr
+	}
 
 	for key, value := range githubConfig.RequestHeaders {
 		request.Header.Add(key, value)
 	}
 
-	authtoken := buildBasicAuth()
-	request.Header.Add("Authorization", authtoken)
+	if authtoken != "" {
+		request.Header.Add("Authorization", authtoken)
+	}
 
 	buildReqQuery(request, reqQueryParam)
 
-	return request
+	return request, nil
 }
 
-// Client for making http request to Github API
-func gitClient(request *http.Request) []byte {
-	client := http.Client{}
+var transportOnce sync.Once
+var transportErr error
+
+// Creates the transport used to connect to the Github API. A custom CA bundle
+// is trusted in addition to the system certificates
+func buildBaseTransport(caBundle string, insecureSkipVerify bool) (http.RoundTripper, error) {
+	if caBundle == "" && insecureSkipVerify == false {
+		return http.DefaultTransport, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caBundle != "" {
+		caPem, err := ioutil.ReadFile(caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		certPool, err := x509.SystemCertPool()
+		if err != nil || certPool == nil {
+			certPool = x509.NewCertPool()
+		}
+
+		if certPool.AppendCertsFromPEM(caPem) == false {
+			return nil, fmt.Errorf("No certificates found in ca-bundle %[1]s", caBundle)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// Configures the shared transport using TLS options from the YAML config.
+// Only runs once, before the first request is made
+func setupTransport() error {
+	transportOnce.Do(func() {
+		base, err := buildBaseTransport(config.YamlConfig.CABundle, config.YamlConfig.InsecureSkipVerify)
+		if err != nil {
+			transportErr = err
+			return
+		}
+		defaultTransport.base = base
+	})
+
+	return transportErr
+}
+
+// Client for making http request to Github API.
+// Returns the response body and headers. Unsuccessful responses
+// are returned as an *APIError
+func gitClient(request *http.Request) ([]byte, http.Header, error) {
+	if err := setupTransport(); err != nil {
+		return nil, nil, err
+	}
+
+	client := http.Client{Transport: defaultTransport}
 
 	res, resperr := client.Do(request)
-	common.CheckErr(resperr)
+	if resperr != nil {
+		return nil, nil, resperr
+	}
+	defer res.Body.Close()
 
 	content, readerr := ioutil.ReadAll(res.Body)
-	common.CheckErr(readerr)
+	if readerr != nil {
+		return nil, nil, readerr
+	}
 
-	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, res.Header, newAPIError(request, res, content)
+	}
 
-	return content
+	return content, res.Header, nil
 }
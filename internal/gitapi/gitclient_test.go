@@ -2,8 +2,12 @@ package gitapi
 
 import (
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/tanmancan/label-it/v1/internal/config"
@@ -60,6 +64,28 @@ func Test_buildEndpoint(t *testing.T) {
 	}
 }
 
+func Test_formatEndpoint(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	tests := []struct {
+		name     string
+		template string
+		args     []interface{}
+		want     string
+	}{
+		{"team members", githubConfig.Endpoints.TeamMembers, []interface{}{"octo-org", "core"}, "/orgs/octo-org/teams/core/members"},
+		{"issue in another repository", githubConfig.Endpoints.GetIssue, []interface{}{"octo-org", "api", 7}, "/repos/octo-org/api/issues/7"},
+		{"app access token", githubConfig.Endpoints.AppAccessToken, []interface{}{"5678"}, "/app/installations/5678/access_tokens"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatEndpoint(tt.template, tt.args...); got != tt.want {
+				t.Errorf("formatEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_buildAPIURL(t *testing.T) {
 	type args struct {
 		endpoint string
@@ -93,6 +119,99 @@ func Test_buildAPIURL(t *testing.T) {
 	}
 }
 
+func Test_buildAPIURL_enterprise(t *testing.T) {
+	t.Cleanup(func() {
+		config.YamlConfig.APIURL = ""
+	})
+	tests := []struct {
+		name   string
+		apiURL string
+		want   string
+	}{
+		{
+			"uses enterprise api path",
+			"https://github.example.com/api/v3",
+			"https://github.example.com/api/v3/repos/Hello/World/pulls",
+		},
+		{
+			"trims trailing slash",
+			"https://github.example.com/api/v3/",
+			"https://github.example.com/api/v3/repos/Hello/World/pulls",
+		},
+		{
+			"does not add api path to host without path",
+			"http://localhost:8080",
+			"http://localhost:8080/repos/Hello/World/pulls",
+		},
+		{
+			"keeps api subdomain without path",
+			"https://api.example.ghe.com",
+			"https://api.example.ghe.com/repos/Hello/World/pulls",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.YamlConfig.APIURL = tt.apiURL
+			if got := buildAPIURL("/repos/Hello/World/pulls"); got != tt.want {
+				t.Errorf("buildAPIURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildBaseTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile, err := ioutil.TempFile("", "label-it-ca-*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(caFile.Name())
+	pem.Encode(caFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caFile.Close()
+
+	emptyFile, err := ioutil.TempFile("", "label-it-empty-*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(emptyFile.Name())
+	emptyFile.Close()
+
+	tests := []struct {
+		name               string
+		caBundle           string
+		insecureSkipVerify bool
+		wantErr            bool
+		wantRequestErr     bool
+	}{
+		{"rejects unknown certificate authority", "", false, false, true},
+		{"trusts custom ca bundle", caFile.Name(), false, false, false},
+		{"skips tls verification", "", true, false, false},
+		{"missing ca bundle", "./does_not_exist.pem", false, true, false},
+		{"ca bundle without certificates", emptyFile.Name(), false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := buildBaseTransport(tt.caBundle, tt.insecureSkipVerify)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildBaseTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			res, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if (err != nil) != tt.wantRequestErr {
+				t.Errorf("request error = %v, wantRequestErr %v", err, tt.wantRequestErr)
+			}
+			if err == nil {
+				res.Body.Close()
+			}
+		})
+	}
+}
+
 func Test_buildBasicAuth(t *testing.T) {
 	fakeuser := "fakeuser"
 	faketoken := "faketoken"
@@ -119,6 +238,41 @@ func Test_buildBasicAuth(t *testing.T) {
 	}
 }
 
+func Test_buildAuthHeader(t *testing.T) {
+	access := config.YamlConfig.Access
+	t.Cleanup(func() {
+		config.YamlConfig.Access = access
+	})
+	tests := []struct {
+		name   string
+		access config.YamlGithubAccess
+		want   string
+	}{
+		{
+			"uses basic auth with user",
+			config.YamlGithubAccess{User: "fakeuser", Token: "faketoken"},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("fakeuser:faketoken")),
+		},
+		{
+			"uses bearer token without user",
+			config.YamlGithubAccess{Token: "github_pat_faketoken"},
+			"Bearer github_pat_faketoken",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.YamlConfig.Access = tt.access
+			got, err := buildAuthHeader()
+			if err != nil {
+				t.Fatalf("buildAuthHeader() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("buildAuthHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_buildReqQuery(t *testing.T) {
 	request, _ := http.NewRequest("GET", "http://example.com", nil)
 	query := map[string]string{
@@ -135,3 +289,17 @@ func Test_buildReqQuery(t *testing.T) {
 		fmt.Println(reqURL)
 	})
 }
+
+// Starts a test server for the Github API, pointing githubConfig.BaseURL
+// at the server until the test ends
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(handler)
+	baseURL := githubConfig.BaseURL
+	githubConfig.BaseURL = server.URL
+	t.Cleanup(func() {
+		githubConfig.BaseURL = baseURL
+		server.Close()
+	})
+
+	return server
+}
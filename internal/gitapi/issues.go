@@ -0,0 +1,209 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tanmancan/label-it/v1/internal/cache"
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+// Issue properties describing an issue linked to a pull request
+type Issue struct {
+	Number      int            `json:"number"`
+	Title       string         `json:"title"`
+	State       string         `json:"state"`
+	Labels      []PrIssueLabel `json:"labels"`
+	PullRequest *struct{}      `json:"pull_request"`
+}
+
+// IssueRef reference to an issue in a pull request body or timeline
+type IssueRef struct {
+	Owner   string
+	Repo    string
+	Number  int
+	Closing bool
+}
+
+// String returns the reference in the "owner/repo#number" format
+func (ref IssueRef) String() string {
+	return fmt.Sprintf("%[1]s/%[2]s#%[3]d", ref.Owner, ref.Repo, ref.Number)
+}
+
+// Matches an issue reference, with an optional closing keyword. References may be
+// "#123", "owner/repo#123" or a URL, ex: https://github.com/owner/repo/issues/123.
+// References must be at the start of the text, or follow whitespace or "("
+var issueRefExp = regexp.MustCompile(
+	`(?i)(?:^|[\s(])(?:(close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+)?` +
+		`(?:https?://([^/\s]+)/([\w.-]+)/([\w.-]+)/(?:issues|pull)/(\d+)|(?:([\w.-]+)/([\w.-]+))?#(\d+))\b`,
+)
+
+// Returns the host of Github web URLs for the configured API. The public API is
+// served from api.github.com, and Github Enterprise from the same host as the web URLs
+func webHost() string {
+	apiURL, err := url.Parse(apiBaseURL())
+	if err != nil {
+		return ""
+	}
+
+	if strings.EqualFold(apiURL.Host, "api.github.com") == true {
+		return "github.com"
+	}
+
+	return apiURL.Host
+}
+
+// ParseIssueRefs finds issue references in a pull request body. References
+// without an owner and repository use the configured repository, and URLs
+// must use the configured Github host. Each issue
+// is only returned once, and is marked closing if any reference to it uses
+// a closing keyword
+// https://docs.github.com/en/issues/tracking-your-work-with-issues/linking-a-pull-request-to-an-issue
+func ParseIssueRefs(body string) []IssueRef {
+	refs := []IssueRef{}
+	index := map[string]int{}
+
+	host := webHost()
+	for _, match := range issueRefExp.FindAllStringSubmatch(body, -1) {
+		if match[2] != "" && strings.EqualFold(match[2], host) == false {
+			continue
+		}
+
+		ref := IssueRef{
+			Owner:   config.YamlConfig.Owner,
+			Repo:    config.YamlConfig.Repo,
+			Closing: match[1] != "",
+		}
+
+		switch {
+		case match[5] != "":
+			ref.Owner, ref.Repo = match[3], match[4]
+			ref.Number, _ = strconv.Atoi(match[5])
+		case match[6] != "":
+			ref.Owner, ref.Repo = match[6], match[7]
+			ref.Number, _ = strconv.Atoi(match[8])
+		default:
+			ref.Number, _ = strconv.Atoi(match[8])
+		}
+
+		key := strings.ToLower(ref.String())
+		if i, found := index[key]; found == true {
+			refs[i].Closing = refs[i].Closing || ref.Closing
+			continue
+		}
+		index[key] = len(refs)
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// A timeline event on a pull request
+type timelineEvent struct {
+	Event  string `json:"event"`
+	Source struct {
+		Issue struct {
+			Number        int    `json:"number"`
+			RepositoryURL string `json:"repository_url"`
+		} `json:"issue"`
+	} `json:"source"`
+}
+
+// ListTimelineRefs get the issues that reference a pull request from its timeline
+// https://docs.github.com/en/rest/reference/issues#list-timeline-events-for-an-issue
+func ListTimelineRefs(number int) ([]IssueRef, error) {
+	endpoint := buildEndpoint(githubConfig.Endpoints.ListTimeline, number)
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	refs := []IssueRef{}
+	err := fetchAllPages(endpoint, query, func(page []byte) error {
+		events := []timelineEvent{}
+		if err := json.Unmarshal(page, &events); err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if event.Event != "cross-referenced" {
+				continue
+			}
+
+			// Repository URL ends with /repos/{owner}/{repo}
+			parts := strings.Split(event.Source.Issue.RepositoryURL, "/")
+			if len(parts) < 2 {
+				continue
+			}
+
+			refs = append(refs, IssueRef{
+				Owner:  parts[len(parts)-2],
+				Repo:   parts[len(parts)-1],
+				Number: event.Source.Issue.Number,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// Caches issues, since the same issue may be linked to several pull requests
+type issueCache struct {
+	cache.Once
+}
+
+var issues = &issueCache{}
+
+// GetIssue get an issue from a reference. Returns nil if the issue does
+// not exist, is not accessible, or is a pull request
+// https://docs.github.com/en/rest/reference/issues#get-an-issue
+func GetIssue(ref IssueRef) (*Issue, error) {
+	return issues.get(ref)
+}
+
+// Returns a cached issue, requesting it if it is not cached
+func (c *issueCache) get(ref IssueRef) (*Issue, error) {
+	issue, err := c.Get(strings.ToLower(ref.String()), func() (interface{}, error) {
+		return requestIssue(ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issue.(*Issue), nil
+}
+
+// Requests an issue. Returns nil if the issue does not exist or is a pull request
+func requestIssue(ref IssueRef) (*Issue, error) {
+	endpoint := formatEndpoint(githubConfig.Endpoints.GetIssue, ref.Owner, ref.Repo, ref.Number)
+	request, err := buildRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedResponse, _, err := gitClient(request)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	issue := &Issue{}
+	if err := json.Unmarshal(parsedResponse, issue); err != nil {
+		return nil, err
+	}
+	if issue.PullRequest != nil {
+		return nil, nil
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,134 @@
+package gitapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestParseIssueRefs(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	tests := []struct {
+		name string
+		body string
+		want []IssueRef
+	}{
+		{"no references", "Updates the readme", []IssueRef{}},
+		{"closing keyword", "Fixes #12", []IssueRef{{"world", "Robot", 12, true}}},
+		{"closing keyword with colon", "resolved: #12", []IssueRef{{"world", "Robot", 12, true}}},
+		{"mention", "Related to #12", []IssueRef{{"world", "Robot", 12, false}}},
+		{"other repository", "Closes octo-org/api#7", []IssueRef{{"octo-org", "api", 7, true}}},
+		{"url", "See https://github.com/octo-org/api/issues/7", []IssueRef{{"octo-org", "api", 7, false}}},
+		{"duplicates", "See #12\nFixes #12", []IssueRef{{"world", "Robot", 12, true}}},
+		{"multiple", "Fixes #12, closes #13 and see #14", []IssueRef{
+			{"world", "Robot", 12, true},
+			{"world", "Robot", 13, true},
+			{"world", "Robot", 14, false},
+		}},
+		{"keyword in word", "prefixes #12", []IssueRef{{"world", "Robot", 12, false}}},
+		{"in parentheses", "Cleanup (fixes #12)", []IssueRef{{"world", "Robot", 12, true}}},
+		{"anchor in word", "see page#12", []IssueRef{}},
+		{"anchor at start of word", "foo#5", []IssueRef{}},
+		{"other repository in word", "see x:octo-org/api#7", []IssueRef{}},
+		{"url on another host", "https://example.com/a/b/issues/3", []IssueRef{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseIssueRefs(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIssueRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("url on enterprise host", func(t *testing.T) {
+		config.YamlConfig.APIURL = "https://ghe.example.com/api/v3"
+		t.Cleanup(func() {
+			config.YamlConfig.APIURL = ""
+		})
+
+		body := "Fixes https://ghe.example.com/octo-org/api/issues/7, see https://github.com/octo-org/api/issues/8"
+		want := []IssueRef{{"octo-org", "api", 7, true}}
+		if got := ParseIssueRefs(body); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseIssueRefs() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestListTimelineRefs(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/world/Robot/issues/42/timeline" {
+			t.Errorf("ListTimelineRefs() path = %v", r.URL.Path)
+		}
+		fmt.Fprint(w, `[
+			{"event":"labeled"},
+			{"event":"cross-referenced","source":{"issue":{"number":7,"repository_url":"https://api.github.com/repos/octo-org/api"}}}
+		]`)
+	})
+
+	got, err := ListTimelineRefs(42)
+	if err != nil {
+		t.Fatalf("ListTimelineRefs() error = %v", err)
+	}
+	want := []IssueRef{{Owner: "octo-org", Repo: "api", Number: 7}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListTimelineRefs() = %v, want %v", got, want)
+	}
+}
+
+func TestGetIssue(t *testing.T) {
+	requests := 0
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/repos/octo-org/api/issues/7":
+			fmt.Fprint(w, `{"number":7,"title":"Broken login","state":"open","labels":[{"name":"area/auth"}]}`)
+		case "/repos/octo-org/api/issues/8":
+			fmt.Fprint(w, `{"number":8,"title":"Fix login","state":"open","pull_request":{}}`)
+		case "/repos/octo-org/private/issues/1":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Bad credentials"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+		}
+	})
+	t.Cleanup(func() {
+		issues = &issueCache{}
+	})
+
+	issue, err := GetIssue(IssueRef{Owner: "octo-org", Repo: "api", Number: 7})
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if issue == nil || issue.Title != "Broken login" || issue.Labels[0].Name != "area/auth" {
+		t.Errorf("GetIssue() = %+v", issue)
+	}
+
+	if _, err := GetIssue(IssueRef{Owner: "Octo-Org", Repo: "API", Number: 7}); err != nil || requests != 1 {
+		t.Errorf("GetIssue() should use the cached issue, requests = %v, error = %v", requests, err)
+	}
+
+	for _, number := range []int{8, 9} {
+		issue, err := GetIssue(IssueRef{Owner: "octo-org", Repo: "api", Number: number})
+		if err != nil || issue != nil {
+			t.Errorf("GetIssue(%[1]d) = %+[2]v, %[3]v, want nil", number, issue, err)
+		}
+	}
+
+	requests = 0
+	for i := 0; i < 2; i++ {
+		if _, err := GetIssue(IssueRef{Owner: "octo-org", Repo: "private", Number: 1}); errors.Is(err, ErrUnauthorized) == false {
+			t.Errorf("GetIssue() error = %v, want %v", err, ErrUnauthorized)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("GetIssue() made %d requests, want the failed request to be cached", requests)
+	}
+}
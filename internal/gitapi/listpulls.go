@@ -2,6 +2,11 @@ package gitapi
 
 import (
 	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
 )
 
 // PrBranch properties describing pull request head and base branch
@@ -16,41 +21,184 @@ type PrUser struct {
 	Login string `json:"login"`
 }
 
+// PrTeam properties describing a team requested to review the pull request
+type PrTeam struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// PrMilestone properties describing the milestone of a pull request
+type PrMilestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// PrIssueLabel properties describing a label on the pull request
+type PrIssueLabel struct {
+	Name string `json:"name"`
+}
+
 // PullRequest individual pull request properties
 type PullRequest struct {
-	URL    string   `json:"url"`
-	Number int      `json:"number"`
-	State  string   `json:"state"`
-	Title  string   `json:"title"`
-	Body   string   `json:"body"`
-	Head   PrBranch `json:"head"`
-	Base   PrBranch `json:"base"`
-	Labels []struct {
-		Name string `json:"name"`
-	} `json:"labels"`
-	User      PrUser `json:"user"`
-	Files     []string
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	URL          string         `json:"url"`
+	Number       int            `json:"number"`
+	State        string         `json:"state"`
+	Draft        bool           `json:"draft"`
+	Title        string         `json:"title"`
+	Body         string         `json:"body"`
+	Head         PrBranch       `json:"head"`
+	Base         PrBranch       `json:"base"`
+	Labels       []PrIssueLabel `json:"labels"`
+	User         PrUser         `json:"user"`
+	Association  string         `json:"author_association"`
+	Milestone    *PrMilestone   `json:"milestone"`
+	Assignees    []PrUser       `json:"assignees"`
+	Reviewers    []PrUser       `json:"requested_reviewers"`
+	Teams        []PrTeam       `json:"requested_teams"`
+	Files        []string
+	CodeOwners   []string
+	LinkedIssues []Issue
+	FileDetails  ListPrFilesResponse
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	ChangedFiles int    `json:"changed_files"`
+	// Mergeable is only returned by the single pull request endpoint, and is nil
+	// while Github is computing the mergeable state
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state"`
+	Reviews        ListReviewsResponse
+	Checks         []PrCheck
+	Commits        ListCommitsResponse
+	Comments       ListCommentsResponse
 }
 
 // ListPullsResponse interface used to unmarshal JSON response
 type ListPullsResponse []PullRequest
 
-// ListPulls get a list of open pull requests
+// PageInfo summary of the pages fetched from a paginated endpoint
+type PageInfo struct {
+	Pages     int
+	Count     int
+	Truncated bool
+}
+
+// Matches a single entry of a Link header, ex: <https://...>; rel="next"
+var linkHeaderExp = regexp.MustCompile(`<([^>]+)>;\s*rel="([^"]+)"`)
+
+// Finds the URL for a given relation in a Link response header
+// https://docs.github.com/en/rest/guides/traversing-with-pagination
+func parseLinkHeader(link string, rel string) string {
+	for _, entry := range linkHeaderExp.FindAllStringSubmatch(link, -1) {
+		if entry[2] == rel {
+			return entry[1]
+		}
+	}
+
+	return ""
+}
+
+// Returns the query parameters of the next page URL in a Link header.
+// Returns nil if there are no more pages
+func nextPageQuery(link string) map[string]string {
+	next := parseLinkHeader(link, "next")
+	if next == "" {
+		return nil
+	}
+
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return nil
+	}
+
+	query := map[string]string{}
+	for key, val := range nextURL.Query() {
+		if len(val) > 0 {
+			query[key] = val[0]
+		}
+	}
+
+	return query
+}
+
+// Requests pages of a paginated endpoint by following the Link header.
+// Each page of the response is passed to the parse function, which returns
+// false to stop before the remaining pages are fetched. Returns true if
+// pages were left unfetched
+func fetchPages(endpoint string, query map[string]string, parse func([]byte) (bool, error)) (bool, error) {
+	for query != nil {
+		request, err := buildRequest("GET", endpoint, nil, query)
+		if err != nil {
+			return false, err
+		}
+
+		parsedResponse, header, err := gitClient(request)
+		if err != nil {
+			return false, err
+		}
+
+		next, err := parse(parsedResponse)
+		if err != nil {
+			return false, err
+		}
+
+		query = nextPageQuery(header.Get("Link"))
+		if next == false {
+			return query != nil, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Requests every page of a paginated endpoint by following the Link header.
+// Each page of the response is passed to the parse function
+func fetchAllPages(endpoint string, query map[string]string, parse func([]byte) error) error {
+	_, err := fetchPages(endpoint, query, func(page []byte) (bool, error) {
+		return true, parse(page)
+	})
+
+	return err
+}
+
+// ListPulls get a list of open pull requests. Github returns a maximum
+// of 100 pull requests per page, so we follow the Link header page by page
+// until all pull requests are fetched or config.MaxPulls is reached.
 // https://docs.github.com/en/rest/reference/pulls#list-pull-requests
-func ListPulls() ListPullsResponse {
+func ListPulls() (ListPullsResponse, PageInfo, error) {
 	endpoint := buildEndpoint(githubConfig.Endpoints.ListPulls)
 
+	perPage := 100
 	query := map[string]string{
-		"state": "open",
+		"state":    "open",
+		"per_page": strconv.Itoa(perPage),
 	}
 
-	request := buildRequest("GET", endpoint, nil, query)
-	parsedResponse := gitClient(request)
-
 	prList := ListPullsResponse{}
-	json.Unmarshal(parsedResponse, &prList)
+	pageInfo := PageInfo{}
+
+	truncated, err := fetchPages(endpoint, query, func(page []byte) (bool, error) {
+		pageInfo.Pages++
+
+		prPage := ListPullsResponse{}
+		if err := json.Unmarshal(page, &prPage); err != nil {
+			return false, err
+		}
+		prList = append(prList, prPage...)
+
+		return config.MaxPulls <= 0 || len(prList) < config.MaxPulls, nil
+	})
+	if err != nil {
+		return nil, pageInfo, err
+	}
+
+	if config.MaxPulls > 0 && len(prList) > config.MaxPulls {
+		truncated = true
+		prList = prList[:config.MaxPulls]
+	}
+	pageInfo.Truncated = truncated
+	pageInfo.Count = len(prList)
 
-	return prList
+	return prList, pageInfo, nil
 }
@@ -0,0 +1,143 @@
+package gitapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func Test_parseLinkHeader(t *testing.T) {
+	link := `<https://api.github.com/repositories/1/pulls?page=2>; rel="next", <https://api.github.com/repositories/1/pulls?page=5>; rel="last"`
+	tests := []struct {
+		name string
+		rel  string
+		want string
+	}{
+		{"finds next page", "next", "https://api.github.com/repositories/1/pulls?page=2"},
+		{"finds last page", "last", "https://api.github.com/repositories/1/pulls?page=5"},
+		{"missing relation", "prev", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLinkHeader(link, tt.rel); got != tt.want {
+				t.Errorf("parseLinkHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nextPageQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want map[string]string
+	}{
+		{
+			"returns query of next page",
+			`<https://api.github.com/repos/a/b/pulls?state=open&per_page=100&page=3>; rel="next"`,
+			map[string]string{"state": "open", "per_page": "100", "page": "3"},
+		},
+		{
+			"returns nil on last page",
+			`<https://api.github.com/repos/a/b/pulls?page=1>; rel="first"`,
+			nil,
+		},
+		{
+			"returns nil without link header",
+			"",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageQuery(tt.link); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nextPageQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Serves a number of open pull requests across pages of 100
+func newPullsServer(t *testing.T, total int) *httptest.Server {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		start := (page - 1) * 100
+		end := start + 100
+		if end >= total {
+			end = total
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%[1]s%[2]s?state=open&per_page=100&page=%[3]d>; rel="next"`, r.Host, r.URL.Path, page+1))
+		}
+		fmt.Fprint(w, "[")
+		for i := start; i < end; i++ {
+			if i > start {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"number":%d}`, i+1)
+		}
+		fmt.Fprint(w, "]")
+	})
+}
+
+func TestListPulls(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		maxPulls int
+		want     PageInfo
+	}{
+		{"single page", 30, 1000, PageInfo{Pages: 1, Count: 30}},
+		{"walks every page", 230, 1000, PageInfo{Pages: 3, Count: 230}},
+		{"no upper bound", 1250, 0, PageInfo{Pages: 13, Count: 1250}},
+		{"stops at upper bound", 450, 150, PageInfo{Pages: 2, Count: 150, Truncated: true}},
+		{"upper bound on last page", 200, 200, PageInfo{Pages: 2, Count: 200}},
+	}
+	maxPulls := config.MaxPulls
+	t.Cleanup(func() {
+		config.MaxPulls = maxPulls
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newPullsServer(t, tt.total)
+			config.MaxPulls = tt.maxPulls
+
+			prList, pageInfo, err := ListPulls()
+			if err != nil {
+				t.Fatalf("ListPulls() error = %v", err)
+			}
+			if pageInfo != tt.want {
+				t.Errorf("ListPulls() pageInfo = %+v, want %+v", pageInfo, tt.want)
+			}
+			if len(prList) != tt.want.Count {
+				t.Errorf("ListPulls() returned %d pull requests, want %d", len(prList), tt.want.Count)
+			}
+			if len(prList) > 0 && prList[len(prList)-1].Number != tt.want.Count {
+				t.Errorf("ListPulls() last pull request = %d, want %d", prList[len(prList)-1].Number, tt.want.Count)
+			}
+		})
+	}
+}
+
+func TestListPulls_error(t *testing.T) {
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Bad credentials","documentation_url":"https://docs.github.com/rest"}`)
+	})
+
+	prList, _, err := ListPulls()
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("ListPulls() error = %v, want %v", err, ErrUnauthorized)
+	}
+	if len(prList) != 0 {
+		t.Errorf("ListPulls() returned %d pull requests on error, want 0", len(prList))
+	}
+}
@@ -2,79 +2,92 @@ package gitapi
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sort"
 	"strconv"
-	"sync"
 )
 
-// PrFile properties describing a changed file in a pull request
+// PrFile properties describing a changed file in a pull request.
+// PreviousFilename is only set for renamed files
 type PrFile struct {
-	SHA      string `json:"sha"`
-	Filename string `json:"filename"`
-	Status   string `json:"status"`
+	SHA              string `json:"sha"`
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
 }
 
 // ListPrFilesResponse A list of files from the list pull request endpoint
 type ListPrFilesResponse []PrFile
 
-// Get a list of changed files for a given pull request number.
-// Github only shows a maximum of 100 files per page, so we are
-// recursively calling the api page by page until we get to the last
-// page with files
+// ErrTooManyFiles returned when a pull request has more changed files than
+// can be listed, instead of returning a partial list of files
+var ErrTooManyFiles = errors.New("too many changed files")
+
+// ListAllFiles get a list of changed files for a given pull request number.
+// Github only shows a maximum of 100 files per page, so we follow the Link
+// header page by page until all files are fetched. Files are sorted by file
+// name. Returns ErrTooManyFiles if the pull request has more than 3000 files
 // https://docs.github.com/en/rest/reference/pulls#list-pull-requests-files
-func listPrFiles(number int, page int, wg *sync.WaitGroup, c chan ListPrFilesResponse) {
+func ListAllFiles(number int) (ListPrFilesResponse, error) {
 	endpoint := buildEndpoint(githubConfig.Endpoints.ListPrFiles, number)
 
 	perPage := 100
 	query := map[string]string{
 		"per_page": strconv.Itoa(perPage),
-		"page":     strconv.Itoa(page),
+		"page":     "1",
 	}
 
-	request := buildRequest("GET", endpoint, nil, query)
+	// The list pr files endpoint returns a maximum of 3000 files (100 per page)
+	maxFiles := 3000
+	maxPages := maxFiles / perPage
 
-	parsedResponse := gitClient(request)
+	allFiles := ListPrFilesResponse{}
+	pages := 0
+	truncated, err := fetchPages(endpoint, query, func(page []byte) (bool, error) {
+		pages++
 
-	prFiles := ListPrFilesResponse{}
-	json.Unmarshal(parsedResponse, &prFiles)
-	c <- prFiles
+		prFiles := ListPrFilesResponse{}
+		if err := json.Unmarshal(page, &prFiles); err != nil {
+			return false, err
+		}
+		allFiles = append(allFiles, prFiles...)
 
-	// Hardcoding 500 max files (100 per page)
-	// Possibly make this configarable, but need to find out
-	// what the constraints are on the list pr files endpoint
-	maxFiles := 1000
-	maxPages := maxFiles / perPage
-	if len(prFiles) == 100 && page <= maxPages-1 {
-		page++
-		wg.Add(1)
-		go listPrFiles(number, page, wg, c)
+		return pages < maxPages, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	wg.Done()
-}
-
-// GetAllFiles calls the get pr files endpoint recursively for
-// each page that return a list of files
-func GetAllFiles(number int) []string {
 
-	c := make(chan ListPrFilesResponse)
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go listPrFiles(number, 1, &wg, c)
-	go func() {
-		wg.Wait()
-		close(c)
-	}()
+	if truncated == true {
+		return nil, fmt.Errorf("%[1]w, only the first %[2]d files can be listed", ErrTooManyFiles, maxFiles)
+	}
 
-	var allFiles ListPrFilesResponse
+	sort.Slice(allFiles, func(i, j int) bool {
+		return allFiles[i].Filename < allFiles[j].Filename
+	})
+	return allFiles, nil
+}
 
-	for res := range c {
-		allFiles = append(allFiles, res...)
+// GetAllFiles returns a sorted list of changed file names for a pull request
+func GetAllFiles(number int) ([]string, error) {
+	allFiles, err := ListAllFiles(number)
+	if err != nil {
+		return nil, err
 	}
 
-	var allFileNames []string
-	for _, files := range allFiles {
-		allFileNames = append(allFileNames, files.Filename)
+	return allFiles.Filenames(), nil
+}
+
+// Filenames returns the file name of each changed file
+func (files ListPrFilesResponse) Filenames() []string {
+	var fileNames []string
+	for _, file := range files {
+		fileNames = append(fileNames, file.Filename)
 	}
-	sort.Strings(allFileNames)
-	return allFileNames
+
+	return fileNames
 }
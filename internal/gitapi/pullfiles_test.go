@@ -0,0 +1,87 @@
+package gitapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestListAllFiles(t *testing.T) {
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"filename":"src/main.go","status":"modified","additions":10,"deletions":2,"changes":12},
+			{"filename":"docs/readme.md","status":"added","additions":5,"deletions":0,"changes":5}
+		]`)
+	})
+
+	files, err := ListAllFiles(3)
+	if err != nil {
+		t.Fatalf("ListAllFiles() error = %v", err)
+	}
+	if fmt.Sprint(files.Filenames()) != "[docs/readme.md src/main.go]" {
+		t.Errorf("ListAllFiles() files = %v, want sorted file names", files.Filenames())
+	}
+	if files[1].Additions != 10 || files[1].Deletions != 2 || files[1].Changes != 12 {
+		t.Errorf("ListAllFiles() file details = %+v", files[1])
+	}
+}
+
+func TestListAllFilesTooMany(t *testing.T) {
+	lastPage := 0
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < lastPage {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%[1]s%[2]s?page=%[3]d>; rel="next"`, r.Host, r.URL.Path, page+1))
+		}
+		files := []string{}
+		for i := 0; i < 100; i++ {
+			files = append(files, fmt.Sprintf(`{"filename":"src/%[1]d-%[2]d.go","status":"added"}`, page, i))
+		}
+		fmt.Fprintf(w, "[%[1]s]", strings.Join(files, ","))
+	})
+
+	t.Run("returns an error instead of a partial list", func(t *testing.T) {
+		lastPage = 31
+		files, err := ListAllFiles(3)
+		if errors.Is(err, ErrTooManyFiles) == false {
+			t.Errorf("ListAllFiles() error = %v, want ErrTooManyFiles", err)
+		}
+		if files != nil {
+			t.Errorf("ListAllFiles() returned %d files, want nil", len(files))
+		}
+	})
+
+	t.Run("lists exactly the maximum number of files", func(t *testing.T) {
+		lastPage = 30
+		files, err := ListAllFiles(3)
+		if err != nil {
+			t.Fatalf("ListAllFiles() error = %v", err)
+		}
+		if len(files) != 3000 {
+			t.Errorf("ListAllFiles() returned %d files, want 3000", len(files))
+		}
+	})
+}
+
+func TestListAllFilesRenamed(t *testing.T) {
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"filename":"src/main.go","status":"modified"},
+			{"filename":"docs/readme.md","previous_filename":"readme.md","status":"renamed"}
+		]`)
+	})
+
+	files, err := ListAllFiles(3)
+	if err != nil {
+		t.Fatalf("ListAllFiles() error = %v", err)
+	}
+	if files[0].Status != "renamed" || files[0].PreviousFilename != "readme.md" {
+		t.Errorf("ListAllFiles() renamed file = %+v", files[0])
+	}
+	if files[1].Status != "modified" || files[1].PreviousFilename != "" {
+		t.Errorf("ListAllFiles() modified file = %+v", files[1])
+	}
+}
@@ -0,0 +1,242 @@
+package gitapi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RequestStats counters describing requests made to the Github API
+type RequestStats struct {
+	Requests    int64
+	Retries     int64
+	RateLimited int64
+}
+
+// Transport that honors Github rate limits and retries transient failures.
+// When the primary rate limit is exhausted, all requests wait until the
+// limit resets. Server errors, 429 and secondary rate limit 403 responses
+// are retried with exponential backoff and jitter.
+// https://docs.github.com/en/rest/overview/resources-in-the-rest-api#rate-limiting
+type rateLimitTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	sleep      func(time.Duration)
+	now        func() time.Time
+
+	mu      sync.Mutex
+	resetAt time.Time
+
+	requests    int64
+	retries     int64
+	rateLimited int64
+}
+
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:       base,
+		maxRetries: 5,
+		baseDelay:  time.Second,
+		maxDelay:   time.Minute,
+		sleep:      time.Sleep,
+		now:        time.Now,
+	}
+}
+
+// Shared transport used by gitClient
+var defaultTransport = newRateLimitTransport(http.DefaultTransport)
+
+// Stats returns counters for all requests made to the Github API during this run
+func Stats() RequestStats {
+	return defaultTransport.stats()
+}
+
+func (t *rateLimitTransport) stats() RequestStats {
+	return RequestStats{
+		Requests:    atomic.LoadInt64(&t.requests),
+		Retries:     atomic.LoadInt64(&t.retries),
+		RateLimited: atomic.LoadInt64(&t.rateLimited),
+	}
+}
+
+// Blocks until the primary rate limit has reset, if it has been exhausted
+func (t *rateLimitTransport) waitForReset() {
+	t.mu.Lock()
+	wait := t.resetAt.Sub(t.now())
+	t.mu.Unlock()
+
+	if wait > 0 {
+		t.sleep(wait)
+	}
+}
+
+// Parses a unix timestamp header value, such as X-RateLimit-Reset
+func parseUnixHeader(header http.Header, key string) (time.Time, bool) {
+	sec, err := strconv.ParseInt(header.Get(key), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(sec, 0), true
+}
+
+// Records when the primary rate limit resets, if no requests remain
+func (t *rateLimitTransport) trackRateLimit(res *http.Response) {
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+
+	reset, ok := parseUnixHeader(res.Header, "X-RateLimit-Reset")
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	if reset.After(t.resetAt) {
+		t.resetAt = reset
+	}
+	t.mu.Unlock()
+}
+
+// Exponential backoff with jitter for a given retry attempt
+func (t *rateLimitTransport) backoff(attempt int) time.Duration {
+	delay := t.baseDelay << uint(attempt)
+	if delay <= 0 || delay > t.maxDelay {
+		delay = t.maxDelay
+	}
+
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// Checks if a 403 response is caused by a primary or secondary rate limit.
+// The response body is restored so it can still be read by the caller.
+func isRateLimitForbidden(res *http.Response) bool {
+	if res.Header.Get("X-RateLimit-Remaining") == "0" || res.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	content, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(content))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(content)), "rate limit")
+}
+
+// Determines if a response should be retried, and how long to wait before retrying
+func (t *rateLimitTransport) retryDelay(res *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests,
+		res.StatusCode == http.StatusForbidden && isRateLimitForbidden(res):
+		atomic.AddInt64(&t.rateLimited, 1)
+
+		if after, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			return time.Duration(after) * time.Second, true
+		}
+
+		// Primary rate limit waits are handled by waitForReset before the next attempt
+		reset, ok := parseUnixHeader(res.Header, "X-RateLimit-Reset")
+		if ok && res.Header.Get("X-RateLimit-Remaining") == "0" && reset.After(t.now()) {
+			return 0, true
+		}
+
+		return t.backoff(attempt), true
+	case res.StatusCode >= 500:
+		return t.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// Creates a copy of the request with a fresh body for retries
+func rewindRequest(request *http.Request) (*http.Request, error) {
+	if request.Body == nil || request.GetBody == nil {
+		return request, nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	retry := request.Clone(request.Context())
+	retry.Body = body
+
+	return retry, nil
+}
+
+// Returns a copy of the request with the Authorization header for the configured
+// access credentials. Requests that already have an Authorization header are not changed
+func authorizeRequest(request *http.Request) (*http.Request, error) {
+	if request.Header.Get("Authorization") != "" {
+		return request, nil
+	}
+
+	authtoken, err := buildAuthHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := request.Clone(request.Context())
+	authorized.Header.Set("Authorization", authtoken)
+
+	return authorized, nil
+}
+
+// RoundTrip implements http.RoundTripper. Requests are authorized before each
+// attempt, since an installation token may expire while waiting for a rate limit reset
+func (t *rateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		t.waitForReset()
+
+		req := request
+		if attempt > 0 {
+			rewound, err := rewindRequest(request)
+			if err != nil {
+				return nil, err
+			}
+			req = rewound
+		}
+
+		req, err := authorizeRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		atomic.AddInt64(&t.requests, 1)
+		res, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		t.trackRateLimit(res)
+
+		delay, retry := t.retryDelay(res, attempt)
+		if retry == false || attempt >= t.maxRetries {
+			return res, nil
+		}
+
+		ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		atomic.AddInt64(&t.retries, 1)
+		if delay > 0 {
+			t.sleep(delay)
+		}
+	}
+}
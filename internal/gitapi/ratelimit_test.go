@@ -0,0 +1,208 @@
+package gitapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+// Creates a transport that records sleeps instead of waiting
+func newTestTransport(now time.Time) (*rateLimitTransport, *[]time.Duration) {
+	var slept []time.Duration
+	transport := newRateLimitTransport(http.DefaultTransport)
+	transport.maxRetries = 3
+	transport.now = func() time.Time {
+		return now
+	}
+	transport.sleep = func(d time.Duration) {
+		slept = append(slept, d)
+		now = now.Add(d)
+	}
+	return transport, &slept
+}
+
+// Serves the given responses in order, repeating the last response
+func newSequenceServer(responses []func(w http.ResponseWriter)) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idx := calls
+		if idx >= len(responses) {
+			idx = len(responses) - 1
+		}
+		calls++
+		responses[idx](w)
+	}))
+	return server, &calls
+}
+
+func respond(status int, headers map[string]string, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for key, val := range headers {
+			w.Header().Set(key, val)
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}
+}
+
+func Test_rateLimitTransport_RoundTrip(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	reset := strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)
+	ok := respond(200, nil, "ok")
+	tests := []struct {
+		name        string
+		responses   []func(w http.ResponseWriter)
+		wantStatus  int
+		wantCalls   int
+		wantStats   RequestStats
+		wantMinWait time.Duration
+	}{
+		{
+			"success is not retried",
+			[]func(w http.ResponseWriter){ok},
+			200, 1, RequestStats{Requests: 1}, 0,
+		},
+		{
+			"retries server errors",
+			[]func(w http.ResponseWriter){respond(502, nil, ""), respond(503, nil, ""), ok},
+			200, 3, RequestStats{Requests: 3, Retries: 2}, time.Second,
+		},
+		{
+			"gives up after max retries",
+			[]func(w http.ResponseWriter){respond(500, nil, "")},
+			500, 4, RequestStats{Requests: 4, Retries: 3}, time.Second,
+		},
+		{
+			"honors retry after",
+			[]func(w http.ResponseWriter){respond(429, map[string]string{"Retry-After": "7"}, ""), ok},
+			200, 2, RequestStats{Requests: 2, Retries: 1, RateLimited: 1}, 7 * time.Second,
+		},
+		{
+			"waits for primary rate limit reset",
+			[]func(w http.ResponseWriter){
+				respond(403, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}, `{"message":"API rate limit exceeded"}`),
+				ok,
+			},
+			200, 2, RequestStats{Requests: 2, Retries: 1, RateLimited: 1}, 30 * time.Second,
+		},
+		{
+			"retries secondary rate limit",
+			[]func(w http.ResponseWriter){
+				respond(403, nil, `{"message":"You have exceeded a secondary rate limit."}`),
+				ok,
+			},
+			200, 2, RequestStats{Requests: 2, Retries: 1, RateLimited: 1}, 500 * time.Millisecond,
+		},
+		{
+			"does not retry forbidden",
+			[]func(w http.ResponseWriter){respond(403, nil, `{"message":"Resource not accessible by integration"}`)},
+			403, 1, RequestStats{Requests: 1}, 0,
+		},
+		{
+			"does not retry not found",
+			[]func(w http.ResponseWriter){respond(404, nil, `{"message":"Not Found"}`)},
+			404, 1, RequestStats{Requests: 1}, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newSequenceServer(tt.responses)
+			defer server.Close()
+			transport, slept := newTestTransport(now)
+			client := http.Client{Transport: transport}
+
+			request, _ := http.NewRequest("POST", server.URL, strings.NewReader(`{"labels":["a"]}`))
+			res, err := client.Do(request)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			ioutil.ReadAll(res.Body)
+			res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("RoundTrip() status = %v, want %v", res.StatusCode, tt.wantStatus)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("RoundTrip() calls = %v, want %v", *calls, tt.wantCalls)
+			}
+			if got := transport.stats(); got != tt.wantStats {
+				t.Errorf("stats() = %+v, want %+v", got, tt.wantStats)
+			}
+			var waited time.Duration
+			for _, d := range *slept {
+				waited += d
+			}
+			if waited < tt.wantMinWait {
+				t.Errorf("RoundTrip() waited %v, want at least %v", waited, tt.wantMinWait)
+			}
+		})
+	}
+}
+
+func Test_rateLimitTransport_replaysBody(t *testing.T) {
+	var bodies []string
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(content))
+		calls++
+		if calls == 1 {
+			w.WriteHeader(502)
+		}
+	}))
+	defer server.Close()
+	transport, _ := newTestTransport(time.Now())
+
+	request, _ := buildRequest("POST", "", []byte(`{"labels":["a"]}`), nil)
+	request.URL, _ = request.URL.Parse(server.URL)
+	res, err := (&http.Client{Transport: transport}).Do(request)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	res.Body.Close()
+
+	if len(bodies) != 2 || bodies[1] != bodies[0] {
+		t.Errorf("retried request body = %v, want original body replayed", bodies)
+	}
+}
+
+func Test_rateLimitTransport_authorizesEachAttempt(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("Authorization"))
+		if len(headers) == 1 {
+			// Credentials change while the request waits to be retried
+			config.YamlConfig.Access.Token = "second"
+			w.WriteHeader(502)
+		}
+	}))
+	defer server.Close()
+	config.YamlConfig.Access = config.YamlGithubAccess{Token: "first"}
+	t.Cleanup(func() {
+		config.YamlConfig.Access = config.YamlGithubAccess{}
+	})
+	transport, _ := newTestTransport(time.Now())
+
+	request, _ := buildRequest("GET", "", nil, nil)
+	request.URL, _ = request.URL.Parse(server.URL)
+	res, err := (&http.Client{Transport: transport}).Do(request)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	res.Body.Close()
+
+	want := "[Bearer first Bearer second]"
+	if fmt.Sprint(headers) != want {
+		t.Errorf("RoundTrip() Authorization headers = %v, want %v", headers, want)
+	}
+	if request.Header.Get("Authorization") != "" {
+		t.Errorf("RoundTrip() should not modify the original request")
+	}
+}
@@ -0,0 +1,42 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// PrReview properties describing a review on a pull request
+type PrReview struct {
+	ID          int    `json:"id"`
+	User        PrUser `json:"user"`
+	State       string `json:"state"`
+	SubmittedAt string `json:"submitted_at"`
+}
+
+// ListReviewsResponse A list of reviews from the list reviews endpoint
+type ListReviewsResponse []PrReview
+
+// ListReviews get all reviews for a given pull request number in
+// chronological order. Follows the Link header until all pages are fetched
+// https://docs.github.com/en/rest/reference/pulls#list-reviews-for-a-pull-request
+func ListReviews(number int) (ListReviewsResponse, error) {
+	endpoint := buildEndpoint(githubConfig.Endpoints.ListReviews, number)
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	reviews := ListReviewsResponse{}
+	err := fetchAllPages(endpoint, query, func(page []byte) error {
+		reviewPage := ListReviewsResponse{}
+		if err := json.Unmarshal(page, &reviewPage); err != nil {
+			return err
+		}
+		reviews = append(reviews, reviewPage...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
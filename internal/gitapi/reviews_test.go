@@ -0,0 +1,36 @@
+package gitapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+)
+
+func TestListReviews(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/world/Robot/pulls/5/reviews" {
+			t.Errorf("ListReviews() path = %v, want /repos/world/Robot/pulls/5/reviews", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":3,"user":{"login":"octocat"},"state":"APPROVED"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%[1]s%[2]s?per_page=100&page=2>; rel="next"`, r.Host, r.URL.Path))
+		fmt.Fprint(w, `[{"id":1,"user":{"login":"octocat"},"state":"CHANGES_REQUESTED"},{"id":2,"user":{"login":"hubot"},"state":"COMMENTED"}]`)
+	})
+
+	reviews, err := ListReviews(5)
+	if err != nil {
+		t.Fatalf("ListReviews() error = %v", err)
+	}
+	if len(reviews) != 3 {
+		t.Fatalf("ListReviews() returned %d reviews, want 3", len(reviews))
+	}
+	if reviews[2].ID != 3 || reviews[2].User.Login != "octocat" || reviews[2].State != "APPROVED" {
+		t.Errorf("ListReviews() last review = %+v", reviews[2])
+	}
+}
@@ -0,0 +1,89 @@
+package gitapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tanmancan/label-it/v1/internal/cache"
+)
+
+// Caches the members of each organization team
+type teamMemberCache struct {
+	cache.Once
+}
+
+var teamMembers = &teamMemberCache{}
+
+// Splits a team in the "org/team-slug" format into the organization and team slug.
+// A leading "@" is ignored, ex: "@org/team-slug"
+func parseTeam(team string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(team, "@"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid team \"%[1]s\". Must use the org/team-slug format", team)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// ListTeamMembers get the usernames of all members of an organization team,
+// including members of child teams. The team uses the "org/team-slug" format
+// https://docs.github.com/en/rest/reference/teams#list-team-members
+func ListTeamMembers(team string) ([]string, error) {
+	org, slug, err := parseTeam(team)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := formatEndpoint(githubConfig.Endpoints.TeamMembers, url.PathEscape(org), url.PathEscape(slug))
+	query := map[string]string{
+		"per_page": strconv.Itoa(100),
+	}
+
+	members := []string{}
+	err = fetchAllPages(endpoint, query, func(page []byte) error {
+		users := []PrUser{}
+		if err := json.Unmarshal(page, &users); err != nil {
+			return err
+		}
+		for _, user := range users {
+			members = append(members, user.Login)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// IsTeamMember checks if a user is a member of an organization team. The team
+// uses the "org/team-slug" format. Team members are only requested once per run
+func IsTeamMember(team string, login string) (bool, error) {
+	return teamMembers.isMember(team, login)
+}
+
+// Checks if a user is a member of a team, requesting the team members if they are not cached
+func (c *teamMemberCache) isMember(team string, login string) (bool, error) {
+	key := strings.ToLower(strings.TrimPrefix(team, "@"))
+	members, err := c.Get(key, func() (interface{}, error) {
+		list, err := ListTeamMembers(team)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to list members of team \"%[1]s\": %[2]w", team, err)
+		}
+
+		members := map[string]bool{}
+		for _, member := range list {
+			members[strings.ToLower(member)] = true
+		}
+		return members, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return members.(map[string]bool)[strings.ToLower(login)], nil
+}
@@ -0,0 +1,101 @@
+package gitapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func Test_parseTeam(t *testing.T) {
+	tests := []struct {
+		name    string
+		team    string
+		org     string
+		slug    string
+		wantErr bool
+	}{
+		{"org and slug", "octo-org/platform", "octo-org", "platform", false},
+		{"leading at sign", "@octo-org/platform", "octo-org", "platform", false},
+		{"missing org", "platform", "", "", true},
+		{"empty slug", "octo-org/", "", "", true},
+		{"nested path", "octo-org/platform/core", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org, slug, err := parseTeam(tt.team)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTeam() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if org != tt.org || slug != tt.slug {
+				t.Errorf("parseTeam() = %v, %v, want %v, %v", org, slug, tt.org, tt.slug)
+			}
+		})
+	}
+}
+
+func TestIsTeamMember(t *testing.T) {
+	requests := 0
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/orgs/octo-org/teams/platform/members" {
+			t.Errorf("IsTeamMember() path = %v, want /orgs/octo-org/teams/platform/members", r.URL.Path)
+		}
+		fmt.Fprint(w, `[{"login":"octocat"},{"login":"Hubot"}]`)
+	})
+	cache := teamMembers
+	teamMembers = &teamMemberCache{}
+	t.Cleanup(func() {
+		teamMembers = cache
+	})
+
+	tests := []struct {
+		team  string
+		login string
+		want  bool
+	}{
+		{"octo-org/platform", "octocat", true},
+		{"@octo-org/platform", "hubot", true},
+		{"octo-org/Platform", "monalisa", false},
+	}
+	for _, tt := range tests {
+		got, err := IsTeamMember(tt.team, tt.login)
+		if err != nil {
+			t.Fatalf("IsTeamMember() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("IsTeamMember(%v, %v) = %v, want %v", tt.team, tt.login, got, tt.want)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("IsTeamMember() made %d requests, want team members to be cached", requests)
+	}
+
+	if _, err := IsTeamMember("platform", "octocat"); err == nil {
+		t.Errorf("IsTeamMember() should return an error for an invalid team")
+	}
+}
+
+func TestIsTeamMember_error(t *testing.T) {
+	requests := 0
+	newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+	cache := teamMembers
+	teamMembers = &teamMemberCache{}
+	t.Cleanup(func() {
+		teamMembers = cache
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := IsTeamMember("octo-org/missing", "octocat"); errors.Is(err, ErrNotFound) == false {
+			t.Errorf("IsTeamMember() error = %v, want %v", err, ErrNotFound)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("IsTeamMember() made %d requests, want the failed request to be cached", requests)
+	}
+}
@@ -0,0 +1,70 @@
+package labeler
+
+import (
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Conclusions of statuses and check runs that are considered failed
+var failedConclusions = config.StringList{"failure", "error", "cancelled", "timed_out", "action_required"}
+
+// Returns the overall state of the statuses and check runs on a commit:
+// failure if any check failed, pending if any check has not completed,
+// success if all checks passed, otherwise none
+func overallChecksState(checks []gitapi.PrCheck) string {
+	if len(checks) == 0 {
+		return "none"
+	}
+
+	pending := false
+	for _, check := range checks {
+		switch {
+		case failedConclusions.Contains(check.Conclusion) == true:
+			return "failure"
+		case check.Conclusion == gitapi.CheckPending:
+			pending = true
+		}
+	}
+
+	if pending == true {
+		return "pending"
+	}
+
+	return "success"
+}
+
+// Checks if any status or check run with the given name has a matching conclusion
+func hasCheck(checks []gitapi.PrCheck, rule config.RuleTypeCheck) bool {
+	for _, check := range checks {
+		if check.Name != rule.Name {
+			continue
+		}
+
+		if len(rule.Conclusion) == 0 || rule.Conclusion.Contains(check.Conclusion) == true {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchChecksRules determines if the statuses and check runs of the pull request
+// head commit match the checks rule
+func (r Rule) MatchChecksRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.ChecksRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	if len(rule.State) > 0 && rule.State.Contains(overallChecksState(pr.Checks)) == false {
+		return false, nil
+	}
+
+	for _, checkRule := range rule.Checks {
+		if hasCheck(pr.Checks, checkRule) == false {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
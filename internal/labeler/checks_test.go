@@ -0,0 +1,61 @@
+package labeler
+
+import (
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+func Test_overallChecksState(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []gitapi.PrCheck
+		want   string
+	}{
+		{"no checks", nil, "none"},
+		{"all passed", []gitapi.PrCheck{{Name: "build", Conclusion: "success"}, {Name: "lint", Conclusion: "skipped"}, {Name: "docs", Conclusion: "neutral"}}, "success"},
+		{"pending check", []gitapi.PrCheck{{Name: "build", Conclusion: "success"}, {Name: "test", Conclusion: "pending"}}, "pending"},
+		{"failed check", []gitapi.PrCheck{{Name: "build", Conclusion: "timed_out"}, {Name: "test", Conclusion: "pending"}}, "failure"},
+		{"errored status", []gitapi.PrCheck{{Name: "ci/deploy", Conclusion: "error"}}, "failure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overallChecksState(tt.checks); got != tt.want {
+				t.Errorf("overallChecksState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchChecksRules(t *testing.T) {
+	pr := gitapi.PullRequest{Checks: []gitapi.PrCheck{
+		{Name: "build", Conclusion: "success"},
+		{Name: "test", Conclusion: "failure"},
+	}}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeChecks
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeChecks{}, true},
+		{"state passes", config.RuleTypeChecks{State: config.ChecksStates{"failure"}}, true},
+		{"state fails", config.RuleTypeChecks{State: config.ChecksStates{"success", "pending"}}, false},
+		{"check with conclusion", config.RuleTypeChecks{Checks: []config.RuleTypeCheck{{Name: "test", Conclusion: config.StringList{"failure", "timed_out"}}}}, true},
+		{"check with other conclusion", config.RuleTypeChecks{Checks: []config.RuleTypeCheck{{Name: "build", Conclusion: config.StringList{"failure"}}}}, false},
+		{"check exists", config.RuleTypeChecks{Checks: []config.RuleTypeCheck{{Name: "build"}}}, true},
+		{"missing check", config.RuleTypeChecks{Checks: []config.RuleTypeCheck{{Name: "build"}, {Name: "deploy"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{ChecksRules: tt.rules}
+			got, err := r.MatchChecksRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchChecksRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchChecksRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package labeler
+
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/tanmancan/label-it/v1/internal/cache"
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Placeholders that may be used in the label of a rule with a codeowners rule.
+// The owner placeholder is replaced with the full owner, ex: @octo-org/frontend.
+// The name placeholder is replaced with the team slug or username, ex: frontend
+const (
+	ownerPlaceholder = "{owner}"
+	namePlaceholder  = "{name}"
+)
+
+// A line in a CODEOWNERS file
+type codeOwnersEntry struct {
+	Pattern string
+	Owners  []string
+}
+
+// Parses the contents of a CODEOWNERS file. Blank lines and comments are ignored.
+// Patterns without owners are kept, as they remove ownership of matching files
+// https://docs.github.com/en/github/creating-cloning-and-archiving-repositories/about-code-owners#codeowners-syntax
+func parseCodeOwners(contents []byte) []codeOwnersEntry {
+	entries := []codeOwnersEntry{}
+	for _, line := range strings.Split(string(contents), "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entries = append(entries, codeOwnersEntry{Pattern: fields[0], Owners: fields[1:]})
+	}
+
+	return entries
+}
+
+// Returns the owners of a file. The last matching pattern takes precedence
+func fileOwners(entries []codeOwnersEntry, filePath string) ([]string, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		matched, err := globMatch(entries[i].Pattern, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if matched == true {
+			return entries[i].Owners, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Returns the sorted, unique owners of all changed files
+func changedFileOwners(entries []codeOwnersEntry, files []string) ([]string, error) {
+	unique := map[string]bool{}
+	for _, file := range files {
+		owners, err := fileOwners(entries, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range owners {
+			unique[owner] = true
+		}
+	}
+
+	owners := []string{}
+	for owner := range unique {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	return owners, nil
+}
+
+// Caches the parsed CODEOWNERS file for each base branch
+type codeOwnersCache struct {
+	cache.Once
+}
+
+var codeOwners = &codeOwnersCache{}
+
+// Returns the parsed CODEOWNERS file for a base branch. A local CODEOWNERS file
+// set in config.YamlConfig.CodeOwnersPath is used for every branch
+func (c *codeOwnersCache) get(ref string) ([]codeOwnersEntry, error) {
+	localPath := config.YamlConfig.CodeOwnersPath
+	if localPath != "" {
+		ref = ""
+	}
+
+	entries, err := c.Get(ref, func() (interface{}, error) {
+		var contents []byte
+		var err error
+		if localPath != "" {
+			contents, err = ioutil.ReadFile(localPath)
+		} else {
+			contents, err = gitapi.GetCodeOwners(ref)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return parseCodeOwners(contents), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries.([]codeOwnersEntry), nil
+}
+
+// Returns the name of an owner, without the organization or leading "@"
+func ownerName(owner string) string {
+	parts := strings.Split(owner, "/")
+	return strings.TrimPrefix(parts[len(parts)-1], "@")
+}
+
+// Replaces the label placeholders with an owner
+func expandLabel(label string, owner string) string {
+	label = strings.ReplaceAll(label, ownerPlaceholder, owner)
+	return strings.ReplaceAll(label, namePlaceholder, ownerName(owner))
+}
+
+// Checks if a label contains an owner placeholder
+func isLabelTemplate(label string) bool {
+	return strings.Contains(label, ownerPlaceholder) || strings.Contains(label, namePlaceholder)
+}
+
+// Expands a rule with a label template into a rule for each label. Each owner
+// of the changed files that passes the codeowners rule checks creates a label.
+// Existing labels created from the template for any owner in the CODEOWNERS
+// file are included, so they can be removed when their owner no longer owns
+// any changed files. Other labels are never included, even if they share the
+// same prefix as the template
+func expandLabelTemplate(r Rule, pr gitapi.PullRequest) (LabelRules, error) {
+	ownerFilter := config.RuleTypeList{RuleTypeString: r.CodeOwnersRules.RuleTypeString}
+	labelOwners := map[string][]string{}
+	var labels []string
+
+	for _, owner := range pr.CodeOwners {
+		matched, err := RuleTypeListValidator(ownerFilter, []string{owner})
+		if err != nil {
+			return nil, err
+		}
+		if matched == false {
+			continue
+		}
+
+		label := expandLabel(r.Label, owner)
+		if _, found := labelOwners[label]; found == false {
+			labels = append(labels, label)
+		}
+		labelOwners[label] = append(labelOwners[label], owner)
+	}
+
+	entries, err := codeOwners.get(pr.Base.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	knownLabels := map[string][]string{}
+	for _, entry := range entries {
+		for _, owner := range entry.Owners {
+			matched, err := RuleTypeListValidator(ownerFilter, []string{owner})
+			if err != nil {
+				return nil, err
+			}
+			if matched == true {
+				label := expandLabel(r.Label, owner)
+				knownLabels[label] = append(knownLabels[label], owner)
+			}
+		}
+	}
+
+	for _, prLabel := range pr.Labels {
+		owners, known := knownLabels[prLabel.Name]
+		if _, found := labelOwners[prLabel.Name]; found == false && known == true {
+			labels = append(labels, prLabel.Name)
+			labelOwners[prLabel.Name] = owners
+		}
+	}
+
+	expanded := LabelRules{}
+	for _, label := range labels {
+		labelRule := r
+		labelRule.Label = label
+		labelRule.templateOwners = labelOwners[label]
+		expanded = append(expanded, labelRule)
+	}
+
+	return expanded, nil
+}
+
+// MatchCodeOwnersRules checks if the CODEOWNERS owners of the changed files match the
+// codeowners rule. Rules expanded from a label template also require the owner of
+// the label to own a changed file
+func (r Rule) MatchCodeOwnersRules(pr gitapi.PullRequest) (bool, error) {
+	if r.templateOwners != nil {
+		owned := false
+		for _, owner := range r.templateOwners {
+			if config.StringList(pr.CodeOwners).Contains(owner) == true {
+				owned = true
+			}
+		}
+		if owned == false {
+			return false, nil
+		}
+	}
+
+	return RuleTypeListValidator(r.CodeOwnersRules, pr.CodeOwners)
+}
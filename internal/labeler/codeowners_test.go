@@ -0,0 +1,213 @@
+package labeler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+const testCodeOwners = `# Default owners
+*                 @octo-org/core
+
+/docs/            @octo-org/docs @octocat
+src/frontend/     @octo-org/frontend   # inline comment
+*.go              @octo-org/backend
+/src/generated/
+`
+
+func Test_fileOwners(t *testing.T) {
+	entries := parseCodeOwners([]byte(testCodeOwners))
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"readme.md", []string{"@octo-org/core"}},
+		{"docs/install.md", []string{"@octo-org/docs", "@octocat"}},
+		{"src/frontend/app.tsx", []string{"@octo-org/frontend"}},
+		{"src/frontend/server.go", []string{"@octo-org/backend"}},
+		{"src/generated/api.go", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got, err := fileOwners(entries, tt.file)
+			if err != nil {
+				t.Fatalf("fileOwners() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("fileOwners() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_changedFileOwners(t *testing.T) {
+	entries := parseCodeOwners([]byte(testCodeOwners))
+	files := []string{"docs/install.md", "src/frontend/app.tsx", "src/frontend/util.tsx", "src/generated/api.go"}
+
+	got, err := changedFileOwners(entries, files)
+	if err != nil {
+		t.Fatalf("changedFileOwners() error = %v", err)
+	}
+	want := []string{"@octo-org/docs", "@octo-org/frontend", "@octocat"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changedFileOwners() = %v, want %v", got, want)
+	}
+}
+
+func Test_codeOwnersCache_local(t *testing.T) {
+	file, err := ioutil.TempFile("", "CODEOWNERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(testCodeOwners)
+	file.Close()
+
+	config.YamlConfig.CodeOwnersPath = file.Name()
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	cache := &codeOwnersCache{}
+	entries, err := cache.get("main")
+	if err != nil {
+		t.Fatalf("codeOwnersCache.get() error = %v", err)
+	}
+	if len(entries) != 5 || entries[1].Pattern != "/docs/" {
+		t.Errorf("codeOwnersCache.get() = %v", entries)
+	}
+
+	// The local file is only read once, and used for every branch
+	os.Remove(file.Name())
+	if entries, err := cache.get("develop"); err != nil || len(entries) != 5 {
+		t.Errorf("codeOwnersCache.get() should cache a local file for every branch, error = %v", err)
+	}
+}
+
+func Test_codeOwnersCache_error(t *testing.T) {
+	file, err := ioutil.TempFile("", "CODEOWNERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	os.Remove(file.Name())
+
+	config.YamlConfig.CodeOwnersPath = file.Name()
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	cache := &codeOwnersCache{}
+	if _, err := cache.get("main"); err == nil {
+		t.Fatalf("codeOwnersCache.get() should return an error for a missing file")
+	}
+
+	// The failed read is cached, even once the file exists
+	if err := ioutil.WriteFile(file.Name(), []byte(testCodeOwners), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := cache.get("develop"); err == nil {
+		t.Errorf("codeOwnersCache.get() should cache a failed read")
+	}
+}
+
+func Test_expandLabelTemplate(t *testing.T) {
+	rule := Rule{
+		Label:               "team/{name}",
+		RemoveWhenUnmatched: true,
+		CodeOwnersRules:     config.RuleTypeList{RuleTypeString: config.RuleTypeString{Match: config.StringList{"^@octo-org/"}}},
+	}
+	pr := gitapi.PullRequest{
+		CodeOwners: []string{"@octo-org/docs", "@octo-org/frontend", "@octocat"},
+		Labels: []gitapi.PrIssueLabel{
+			{Name: "team/frontend"},
+			{Name: "team/backend"},
+			{Name: "team/manual"},
+			{Name: "bug"},
+		},
+	}
+
+	file, err := ioutil.TempFile("", "CODEOWNERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(testCodeOwners)
+	file.Close()
+
+	cache := codeOwners
+	codeOwners = &codeOwnersCache{}
+	config.YamlConfig.CodeOwnersPath = file.Name()
+	t.Cleanup(func() {
+		codeOwners = cache
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+
+	expanded, err := expandLabelTemplate(rule, pr)
+	if err != nil {
+		t.Fatalf("expandLabelTemplate() error = %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, r := range expanded {
+		matched, err := r.MatchAllRules(pr)
+		if err != nil {
+			t.Fatalf("Rule.MatchAllRules() error = %v", err)
+		}
+		got[r.Label] = matched
+	}
+	// team/manual is not created from an owner in the CODEOWNERS file, so it is left unchanged
+	want := map[string]bool{"team/docs": true, "team/frontend": true, "team/backend": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandLabelTemplate() labels = %v, want %v", got, want)
+	}
+}
+
+func Test_expandLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		owner string
+		want  string
+	}{
+		{"team/{name}", "@octo-org/frontend", "team/frontend"},
+		{"owner: {owner}", "@octo-org/frontend", "owner: @octo-org/frontend"},
+		{"user/{name}", "@octocat", "user/octocat"},
+	}
+	for _, tt := range tests {
+		if got := expandLabel(tt.label, tt.owner); got != tt.want {
+			t.Errorf("expandLabel(%v, %v) = %v, want %v", tt.label, tt.owner, got, tt.want)
+		}
+	}
+}
+
+func TestRule_MatchCodeOwnersRules(t *testing.T) {
+	pr := gitapi.PullRequest{CodeOwners: []string{"@octo-org/docs", "@octocat"}}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeList
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeList{}, true},
+		{"owner of a changed file", config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"@octo-org/docs"}}}, true},
+		{"not an owner of a changed file", config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"@octo-org/frontend"}}}, false},
+		{"no-exact owner", config.RuleTypeList{RuleTypeString: config.RuleTypeString{NoExact: config.StringList{"@octocat"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{CodeOwnersRules: tt.rules}
+			got, err := r.MatchCodeOwnersRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchCodeOwnersRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchCodeOwnersRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package labeler
+
+import (
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Author associations of repository maintainers
+var maintainerAssociations = config.StringList{"OWNER", "MEMBER", "COLLABORATOR"}
+
+// Returns the comments checked by a comment rule. Comments are limited to
+// maintainers first, then to the most recent comment
+func filterComments(rule config.RuleTypeComment, comments gitapi.ListCommentsResponse) gitapi.ListCommentsResponse {
+	filtered := gitapi.ListCommentsResponse{}
+	for _, comment := range comments {
+		if rule.Maintainers == true && maintainerAssociations.Contains(comment.Association) == false {
+			continue
+		}
+		filtered = append(filtered, comment)
+	}
+
+	if rule.Latest == true && len(filtered) > 0 {
+		return filtered[len(filtered)-1:]
+	}
+
+	return filtered
+}
+
+// MatchCommentRules determines if the comments on a pull request match the comment rule.
+// Each comment is checked against the text and author checks, and the
+// quantifier determines how many comments must pass
+func (r Rule) MatchCommentRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.CommentRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	comments := filterComments(rule, pr.Comments)
+
+	return matchQuantifier(rule.Quantifier, rule.MinCount, len(comments), func(i int) (bool, error) {
+		matched, err := RuleTypeStringValidator(rule.Text, comments[i].Body)
+		if err != nil || matched == false {
+			return false, err
+		}
+
+		return RuleTypeStringValidator(rule.Author, comments[i].User.Login)
+	})
+}
@@ -0,0 +1,81 @@
+package labeler
+
+import (
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Creates a comment from a body, Github username and author association
+func newComment(body string, login string, association string) gitapi.PrComment {
+	return gitapi.PrComment{Body: body, User: gitapi.PrUser{Login: login}, Association: association}
+}
+
+func TestRule_MatchCommentRules(t *testing.T) {
+	pr := gitapi.PullRequest{Comments: gitapi.ListCommentsResponse{
+		newComment("/label needs-qa", "octocat", "MEMBER"),
+		newComment("LGTM", "monalisa", "COLLABORATOR"),
+		newComment("/label needs-qa please", "hubot", "NONE"),
+	}}
+	needsQa := config.RuleTypeString{Match: config.StringList{"(?m)^/label needs-qa"}}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeComment
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeComment{}, true},
+		{"any comment text matches", config.RuleTypeComment{Text: needsQa}, true},
+		{"no comment text matches", config.RuleTypeComment{Text: config.RuleTypeString{Exact: config.StringList{"/hold"}}}, false},
+		{"comment author", config.RuleTypeComment{Text: config.RuleTypeString{Exact: config.StringList{"LGTM"}}, Author: config.RuleTypeString{Exact: config.StringList{"monalisa"}}}, true},
+		{"text and author must match the same comment", config.RuleTypeComment{Text: config.RuleTypeString{Exact: config.StringList{"LGTM"}}, Author: config.RuleTypeString{Exact: config.StringList{"octocat"}}}, false},
+		{"min-count", config.RuleTypeComment{Text: needsQa, MinCount: 2}, true},
+		{"maintainers only", config.RuleTypeComment{Text: needsQa, MinCount: 2, Maintainers: true}, false},
+		{"latest comment", config.RuleTypeComment{Text: needsQa, Latest: true}, true},
+		{"latest maintainer comment", config.RuleTypeComment{Text: needsQa, Latest: true, Maintainers: true}, false},
+		{"no comments match", config.RuleTypeComment{Author: config.RuleTypeString{Match: config.StringList{"\\[bot\\]$"}}, Quantifier: config.QuantifierNone}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{CommentRules: tt.rules}
+			got, err := r.MatchCommentRules(pr)
+			if err != nil {
+				t.Fatalf("Rule.MatchCommentRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchCommentRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchCommentRules_filters(t *testing.T) {
+	comments := gitapi.ListCommentsResponse{
+		newComment("LGTM", "monalisa", "COLLABORATOR"),
+		newComment("/label needs-qa please", "hubot", "NONE"),
+	}
+	tests := []struct {
+		name     string
+		rules    config.RuleTypeComment
+		comments gitapi.ListCommentsResponse
+		want     bool
+	}{
+		{"maintainer comment", config.RuleTypeComment{Maintainers: true}, comments, true},
+		{"no maintainer comment", config.RuleTypeComment{Maintainers: true}, comments[1:], false},
+		{"latest comment", config.RuleTypeComment{Latest: true}, comments, true},
+		{"no comments", config.RuleTypeComment{Latest: true}, nil, false},
+		{"no maintainer comments", config.RuleTypeComment{Maintainers: true, Quantifier: config.QuantifierNone}, comments[1:], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{CommentRules: tt.rules}
+			got, err := r.MatchCommentRules(gitapi.PullRequest{Comments: tt.comments})
+			if err != nil {
+				t.Fatalf("Rule.MatchCommentRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchCommentRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
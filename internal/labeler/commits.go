@@ -0,0 +1,26 @@
+package labeler
+
+import (
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// MatchCommitRules determines if the commits in a pull request match the commit rule.
+// Each commit is checked against the message and author checks, and the
+// quantifier determines how many commits must pass
+func (r Rule) MatchCommitRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.CommitRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	commits := pr.Commits
+
+	return matchQuantifier(rule.Quantifier, rule.MinCount, len(commits), func(i int) (bool, error) {
+		matched, err := RuleTypeStringValidator(rule.Message, commits[i].Commit.Message)
+		if err != nil || matched == false {
+			return false, err
+		}
+
+		return RuleTypeStringValidator(rule.Author, commits[i].AuthorLogin())
+	})
+}
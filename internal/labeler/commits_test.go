@@ -0,0 +1,86 @@
+package labeler
+
+import (
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Creates a commit from a message and Github username
+func newCommit(message string, login string) gitapi.PrCommit {
+	return gitapi.PrCommit{
+		Commit: gitapi.PrCommitDetails{Message: message},
+		Author: &gitapi.PrUser{Login: login},
+	}
+}
+
+func TestRule_MatchCommitRules(t *testing.T) {
+	pr := gitapi.PullRequest{Commits: gitapi.ListCommitsResponse{
+		newCommit("feat!: remove v1 api\n\nBREAKING CHANGE: v1 endpoints are removed", "octocat"),
+		newCommit("fix: handle empty body", "octocat"),
+		newCommit("chore: update dependencies", "dependabot[bot]"),
+	}}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeCommit
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeCommit{}, true},
+		{
+			"any commit message matches",
+			config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"BREAKING CHANGE", "^[a-z]+(\\(.+\\))?!:"}}},
+			true,
+		},
+		{
+			"conventional commit breaking change type",
+			config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"^[a-z]+(\\(.+\\))?!:"}}},
+			true,
+		},
+		{
+			"no commit message matches",
+			config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"^revert"}}},
+			false,
+		},
+		{
+			"all commit messages match",
+			config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"^(feat|fix|chore)"}}, Quantifier: config.QuantifierAll},
+			true,
+		},
+		{
+			"all commit messages do not match",
+			config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"^fix"}}, Quantifier: config.QuantifierAll},
+			false,
+		},
+		{
+			"none of the commits match",
+			config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"WIP"}}, Quantifier: config.QuantifierNone},
+			true,
+		},
+		{
+			"message and author must match the same commit",
+			config.RuleTypeCommit{
+				Message: config.RuleTypeString{Match: config.StringList{"^fix"}},
+				Author:  config.RuleTypeString{Exact: config.StringList{"dependabot[bot]"}},
+			},
+			false,
+		},
+		{
+			"author min count",
+			config.RuleTypeCommit{Author: config.RuleTypeString{Exact: config.StringList{"octocat"}}, MinCount: 2},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{CommitRules: tt.rules}
+			got, err := r.MatchCommitRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchCommitRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchCommitRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
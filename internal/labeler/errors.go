@@ -0,0 +1,45 @@
+package labeler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PrError error encountered while processing an individual pull request
+type PrError struct {
+	Issue int
+	Err   error
+}
+
+// Error implements the error interface
+func (e PrError) Error() string {
+	return fmt.Sprintf("PR #%[1]d: %[2]s", e.Issue, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e PrError) Unwrap() error {
+	return e.Err
+}
+
+// PrErrors list of errors collected while processing pull requests.
+// A failure in one pull request does not stop other pull requests
+// from being processed
+type PrErrors []PrError
+
+// Error implements the error interface
+func (e PrErrors) Error() string {
+	messages := []string{}
+	for _, prErr := range e {
+		messages = append(messages, prErr.Error())
+	}
+
+	return fmt.Sprintf("%[1]d pull request(s) failed:\n%[2]s", len(e), strings.Join(messages, "\n"))
+}
+
+// Sorts errors by pull request number
+func (e PrErrors) sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].Issue < e[j].Issue
+	})
+}
@@ -0,0 +1,108 @@
+package labeler
+
+import (
+	"path"
+	"strings"
+)
+
+// Matches path segments against glob pattern segments. The "**" segment
+// matches zero or more directories, other segments use path.Match
+func matchGlobSegments(patternParts []string, pathParts []string) (bool, error) {
+	if len(patternParts) == 0 {
+		return len(pathParts) == 0, nil
+	}
+
+	if patternParts[0] == "**" {
+		// A trailing ** matches everything inside a directory, but not the directory itself
+		if len(patternParts) == 1 {
+			return len(pathParts) > 0, nil
+		}
+
+		for i := 0; i <= len(pathParts); i++ {
+			matched, err := matchGlobSegments(patternParts[1:], pathParts[i:])
+			if err != nil || matched == true {
+				return matched, err
+			}
+		}
+
+		return false, nil
+	}
+
+	if len(pathParts) == 0 {
+		return false, nil
+	}
+
+	matched, err := path.Match(patternParts[0], pathParts[0])
+	if err != nil || matched == false {
+		return false, err
+	}
+
+	return matchGlobSegments(patternParts[1:], pathParts[1:])
+}
+
+// Checks if a file path matches a glob pattern using gitignore style semantics,
+// similar to patterns in a CODEOWNERS file:
+// - A pattern without a slash, such as "*.md", matches at any depth.
+// - A pattern with a leading or middle slash is relative to the repository root.
+// - A pattern matching a directory, such as "docs/", matches all files within it.
+// - A pattern ending in "/*" only matches files directly inside the directory.
+// - "**" matches zero or more directories.
+func globMatch(pattern string, filePath string) (bool, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	if pattern == "" {
+		return false, nil
+	}
+
+	if strings.Contains(pattern, "/") == false {
+		pattern = "**/" + pattern
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(filePath, "/")
+
+	matched, err := matchGlobSegments(patternParts, pathParts)
+	if err != nil || (matched == true && dirOnly == false) {
+		return matched, err
+	}
+
+	if patternParts[len(patternParts)-1] == "*" {
+		return false, nil
+	}
+
+	// A pattern matching a parent directory matches all files within it
+	for end := len(pathParts) - 1; end > 0; end-- {
+		matched, err := matchGlobSegments(patternParts, pathParts[:end])
+		if err != nil || matched == true {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+// Checks if any glob pattern matches a file path
+func globAny(patterns []string, filePath string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := globMatch(pattern, filePath)
+		if err != nil || matched == true {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+// Checks if any glob pattern matches any file path
+func globAnyFile(files []string, patterns []string) (bool, error) {
+	for _, file := range files {
+		matched, err := globAny(patterns, file)
+		if err != nil || matched == true {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,55 @@
+package labeler
+
+import "testing"
+
+func Test_globMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		filePath string
+		want     bool
+	}{
+		{"*.md", "readme.md", true},
+		{"*.md", "docs/guides/setup.md", true},
+		{"*.md", "docs/guides/setup.txt", false},
+		{"/readme.md", "readme.md", true},
+		{"/readme.md", "docs/readme.md", false},
+		{"docs/", "docs/guides/setup.md", true},
+		{"docs/", "src/docs/index.ts", true},
+		{"docs/", "docs", false},
+		{"/docs/", "src/docs/index.ts", false},
+		{"docs/*", "docs/setup.md", true},
+		{"docs/*", "docs/guides/setup.md", false},
+		{"docs/**", "docs/guides/setup.md", true},
+		{"docs/**", "docs", false},
+		{"src/frontend/**/*.tsx", "src/frontend/App.tsx", true},
+		{"src/frontend/**/*.tsx", "src/frontend/components/nav/Menu.tsx", true},
+		{"src/frontend/**/*.tsx", "src/frontend/components/nav/Menu.ts", false},
+		{"src/frontend/**/*.tsx", "lib/src/frontend/App.tsx", false},
+		{"**/migrations/*.sql", "db/migrations/0001_init.sql", true},
+		{"**/migrations/*.sql", "migrations/0001_init.sql", true},
+		{"apps/github", "apps/github/index.js", true},
+		{"apps/github", "apps/github", true},
+		{"apps/github", "apps/github-actions/index.js", false},
+		{"logs", "build/logs/output.log", true},
+		{"?.go", "a.go", true},
+		{"[ab].go", "c.go", false},
+		{"", "readme.md", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.filePath, func(t *testing.T) {
+			got, err := globMatch(tt.pattern, tt.filePath)
+			if err != nil {
+				t.Errorf("globMatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.filePath, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_globMatch_invalidPattern(t *testing.T) {
+	if _, err := globMatch("[a-", "[a-"); err == nil {
+		t.Errorf("globMatch() should return an error for an invalid pattern")
+	}
+}
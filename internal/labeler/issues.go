@@ -0,0 +1,145 @@
+package labeler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Returns the references to issues linked to a pull request. Body references may be
+// limited to closing references, and timeline cross references may be included,
+// depending on config.YamlConfig.LinkedIssues
+func linkedIssueRefs(pr gitapi.PullRequest) ([]gitapi.IssueRef, error) {
+	options := config.YamlConfig.LinkedIssues
+	refs := []gitapi.IssueRef{}
+
+	for _, ref := range gitapi.ParseIssueRefs(pr.Body) {
+		if options.ClosingOnly == true && ref.Closing == false {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	if options.Timeline == true {
+		timelineRefs, err := gitapi.ListTimelineRefs(pr.Number)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, timelineRefs...)
+	}
+
+	// Remove duplicates and references to the pull request itself
+	self := gitapi.IssueRef{Owner: config.YamlConfig.Owner, Repo: config.YamlConfig.Repo, Number: pr.Number}
+	seen := map[string]bool{strings.ToLower(self.String()): true}
+	unique := []gitapi.IssueRef{}
+	for _, ref := range refs {
+		key := strings.ToLower(ref.String())
+		if seen[key] == true {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, ref)
+	}
+
+	return unique, nil
+}
+
+// Fetches the issues linked to a pull request. References to pull requests,
+// or to issues that do not exist, are ignored
+func linkedIssues(pr gitapi.PullRequest) ([]gitapi.Issue, error) {
+	refs, err := linkedIssueRefs(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []gitapi.Issue{}
+	for _, ref := range refs {
+		issue, err := gitapi.GetIssue(ref)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// Returns the sorted, unique labels of all linked issues
+func issueLabels(issues []gitapi.Issue) []string {
+	unique := map[string]bool{}
+	for _, issue := range issues {
+		for _, label := range issue.Labels {
+			unique[label.Name] = true
+		}
+	}
+
+	labels := []string{}
+	for label := range unique {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	return labels
+}
+
+// Expands a rule with inherit-labels into a rule for each label of the linked issues
+// that passes the inherit-labels checks. Labels are only copied, so existing pull
+// request labels are never included
+func expandInheritLabels(r Rule, pr gitapi.PullRequest) (LabelRules, error) {
+	labels := []string{}
+	for _, label := range issueLabels(pr.LinkedIssues) {
+		matched, err := RuleTypeStringValidator(r.InheritLabels, label)
+		if err != nil {
+			return nil, err
+		}
+		if matched == true {
+			labels = append(labels, label)
+		}
+	}
+
+	expanded := LabelRules{}
+	for _, label := range labels {
+		labelRule := r
+		labelRule.Label = label
+		labelRule.inherited = true
+		expanded = append(expanded, labelRule)
+	}
+
+	return expanded, nil
+}
+
+// MatchIssueRules checks if the issues linked to a pull request match the issue rule.
+// Rules expanded from inherit-labels also require a linked issue to have the label
+func (r Rule) MatchIssueRules(pr gitapi.PullRequest) (bool, error) {
+	labels := issueLabels(pr.LinkedIssues)
+	if r.inherited == true && config.StringList(labels).Contains(r.Label) == false {
+		return false, nil
+	}
+
+	rule := r.IssueRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	if rule.Linked != nil && *rule.Linked != (len(pr.LinkedIssues) > 0) {
+		return false, nil
+	}
+
+	if len(rule.State) > 0 {
+		matched := false
+		for _, issue := range pr.LinkedIssues {
+			if rule.State.Contains(issue.State) == true {
+				matched = true
+			}
+		}
+		if matched == false {
+			return false, nil
+		}
+	}
+
+	return RuleTypeListValidator(rule.Labels, labels)
+}
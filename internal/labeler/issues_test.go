@@ -0,0 +1,108 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+func Test_linkedIssueRefs(t *testing.T) {
+	config.YamlConfig.Owner = "world"
+	config.YamlConfig.Repo = "Robot"
+	t.Cleanup(func() {
+		config.YamlConfig.LinkedIssues = config.YamlLinkedIssues{}
+	})
+	pr := gitapi.PullRequest{Number: 42, Body: "Fixes #12 and world/robot#42, related to #13"}
+
+	got, err := linkedIssueRefs(pr)
+	if err != nil {
+		t.Fatalf("linkedIssueRefs() error = %v", err)
+	}
+	want := []gitapi.IssueRef{
+		{Owner: "world", Repo: "Robot", Number: 12, Closing: true},
+		{Owner: "world", Repo: "Robot", Number: 13},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("linkedIssueRefs() = %v, want %v", got, want)
+	}
+
+	config.YamlConfig.LinkedIssues.ClosingOnly = true
+	got, err = linkedIssueRefs(pr)
+	if err != nil {
+		t.Fatalf("linkedIssueRefs() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("linkedIssueRefs() closing-only = %v, want %v", got, want[:1])
+	}
+}
+
+func Test_expandInheritLabels(t *testing.T) {
+	rule := Rule{
+		InheritLabels: config.RuleTypeString{Match: config.StringList{"^area/"}},
+	}
+	pr := gitapi.PullRequest{
+		LinkedIssues: []gitapi.Issue{
+			{Number: 12, Labels: []gitapi.PrIssueLabel{{Name: "area/auth"}, {Name: "bug"}}},
+			{Number: 13, Labels: []gitapi.PrIssueLabel{{Name: "area/api"}, {Name: "area/auth"}}},
+		},
+		Labels: []gitapi.PrIssueLabel{{Name: "area/api"}, {Name: "area/docs"}, {Name: "bug"}},
+	}
+
+	expanded, err := expandInheritLabels(rule, pr)
+	if err != nil {
+		t.Fatalf("expandInheritLabels() error = %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, r := range expanded {
+		matched, err := r.MatchAllRules(pr)
+		if err != nil {
+			t.Fatalf("Rule.MatchAllRules() error = %v", err)
+		}
+		got[r.Label] = matched
+	}
+	// area/docs is only on the pull request, so it is left unchanged
+	want := map[string]bool{"area/api": true, "area/auth": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandInheritLabels() labels = %v, want %v", got, want)
+	}
+}
+
+func TestRule_MatchIssueRules(t *testing.T) {
+	yes, no := true, false
+	pr := gitapi.PullRequest{
+		LinkedIssues: []gitapi.Issue{
+			{Number: 12, State: "open", Labels: []gitapi.PrIssueLabel{{Name: "area/auth"}}},
+			{Number: 13, State: "closed", Labels: []gitapi.PrIssueLabel{{Name: "bug"}}},
+		},
+	}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeIssue
+		pr    gitapi.PullRequest
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeIssue{}, pr, true},
+		{"linked", config.RuleTypeIssue{Linked: &yes}, pr, true},
+		{"not linked", config.RuleTypeIssue{Linked: &no}, pr, false},
+		{"no linked issues", config.RuleTypeIssue{Linked: &no}, gitapi.PullRequest{}, true},
+		{"issue state", config.RuleTypeIssue{State: config.StringList{"closed"}}, pr, true},
+		{"no issue with state", config.RuleTypeIssue{State: config.StringList{"open"}}, gitapi.PullRequest{}, false},
+		{"issue label", config.RuleTypeIssue{Labels: config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"bug"}}}}, pr, true},
+		{"no issue label", config.RuleTypeIssue{Labels: config.RuleTypeList{RuleTypeString: config.RuleTypeString{NoMatch: config.StringList{"^area/"}}}}, pr, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{IssueRules: tt.rules}
+			got, err := r.MatchIssueRules(tt.pr)
+			if err != nil {
+				t.Fatalf("Rule.MatchIssueRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchIssueRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
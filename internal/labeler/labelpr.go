@@ -2,20 +2,69 @@ package labeler
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/tanmancan/label-it/v1/internal/gitapi"
 )
 
-// LabelPr adds labels to a given list of pull requests via the Github API
-func LabelPr(prLabels []gitapi.PrLabel) {
+// Result of adding labels to an individual pull request
+type labelResult struct {
+	prLabel gitapi.PrLabel
+	err     error
+}
+
+// Adds and removes labels for an individual pull request
+func updatePrLabels(prLabel gitapi.PrLabel) error {
+	err := gitapi.AddLabels(prLabel)
+	if err != nil {
+		return err
+	}
+
+	return gitapi.RemoveLabels(prLabel)
+}
+
+// LabelPr adds and removes labels for a given list of pull requests via the Github API.
+// Pull requests that could not be labeled are returned as PrErrors
+func LabelPr(prLabels []gitapi.PrLabel) error {
 	updateCount := len(prLabels)
 
-	c := make(chan string, updateCount)
+	c := make(chan labelResult, updateCount)
 	for _, prLabel := range prLabels {
-		go gitapi.AddLabels(prLabel, c)
+		go func(prLabel gitapi.PrLabel) {
+			c <- labelResult{prLabel, updatePrLabels(prLabel)}
+		}(prLabel)
 	}
 
+	prErrors := PrErrors{}
 	for i := 0; i < updateCount; i++ {
-		fmt.Println(<-c)
+		result := <-c
+
+		if result.err != nil {
+			prErrors = append(prErrors, PrError{result.prLabel.Issue, result.err})
+			continue
+		}
+
+		if len(result.prLabel.Labels) > 0 {
+			fmt.Printf(
+				"Added label(s) \"%[1]s\" to PR #%[2]d\n",
+				strings.Join(result.prLabel.Labels, ", "),
+				result.prLabel.Issue,
+			)
+		}
+
+		if len(result.prLabel.Remove) > 0 {
+			fmt.Printf(
+				"Removed label(s) \"%[1]s\" from PR #%[2]d\n",
+				strings.Join(result.prLabel.Remove, ", "),
+				result.prLabel.Issue,
+			)
+		}
 	}
+
+	if len(prErrors) > 0 {
+		prErrors.sort()
+		return prErrors
+	}
+
+	return nil
 }
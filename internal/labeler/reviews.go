@@ -0,0 +1,106 @@
+package labeler
+
+import (
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Review states returned by the Github API
+const (
+	reviewApproved         = "APPROVED"
+	reviewChangesRequested = "CHANGES_REQUESTED"
+	reviewCommented        = "COMMENTED"
+	reviewPending          = "PENDING"
+)
+
+// Returns the latest review state of each reviewer. Reviews are in
+// chronological order. A comment does not replace an earlier approval
+// or request for changes, and pending reviews are ignored
+func latestReviewStates(reviews gitapi.ListReviewsResponse) map[string]string {
+	states := map[string]string{}
+	for _, review := range reviews {
+		login := review.User.Login
+		switch review.State {
+		case reviewPending:
+			continue
+		case reviewCommented:
+			if _, found := states[login]; found == true {
+				continue
+			}
+		}
+		states[login] = review.State
+	}
+
+	return states
+}
+
+// Returns the overall review state of a pull request: changes-requested if
+// any reviewer requested changes, approved if any reviewer approved,
+// commented if any reviewer commented, otherwise none
+func overallReviewState(states map[string]string) string {
+	approved := false
+	commented := false
+	for _, state := range states {
+		switch state {
+		case reviewChangesRequested:
+			return "changes-requested"
+		case reviewApproved:
+			approved = true
+		case reviewCommented:
+			commented = true
+		}
+	}
+
+	switch {
+	case approved == true:
+		return "approved"
+	case commented == true:
+		return "commented"
+	}
+
+	return "none"
+}
+
+// Checks if any of the users has the given latest review state.
+// An empty state matches any review
+func reviewedByAny(states map[string]string, users config.StringList, state string) bool {
+	for _, user := range users {
+		userState, found := states[user]
+		if found == true && (state == "" || userState == state) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchReviewRules determines if the latest reviews on a pull request match the review rule
+func (r Rule) MatchReviewRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.ReviewRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	states := latestReviewStates(pr.Reviews)
+
+	if len(rule.State) > 0 && rule.State.Contains(overallReviewState(states)) == false {
+		return false, nil
+	}
+
+	approvals := 0
+	for _, state := range states {
+		if state == reviewApproved {
+			approvals++
+		}
+	}
+
+	switch {
+	case RuleTypeCountValidator(rule.Approvals, approvals) == false,
+		len(rule.ApprovedBy) > 0 && reviewedByAny(states, rule.ApprovedBy, reviewApproved) == false,
+		len(rule.ChangesRequestedBy) > 0 && reviewedByAny(states, rule.ChangesRequestedBy, reviewChangesRequested) == false,
+		len(rule.ReviewedBy) > 0 && reviewedByAny(states, rule.ReviewedBy, "") == false:
+		return false, nil
+	}
+
+	return true, nil
+}
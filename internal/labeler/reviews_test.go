@@ -0,0 +1,103 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tanmancan/label-it/v1/internal/config"
+	"github.com/tanmancan/label-it/v1/internal/gitapi"
+)
+
+// Creates a review from a user login and review state
+func newReview(login string, state string) gitapi.PrReview {
+	return gitapi.PrReview{User: gitapi.PrUser{Login: login}, State: state}
+}
+
+func Test_latestReviewStates(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews gitapi.ListReviewsResponse
+		want    map[string]string
+	}{
+		{"no reviews", nil, map[string]string{}},
+		{
+			"latest state replaces earlier state",
+			gitapi.ListReviewsResponse{newReview("octocat", "CHANGES_REQUESTED"), newReview("octocat", "APPROVED")},
+			map[string]string{"octocat": "APPROVED"},
+		},
+		{
+			"comment does not replace earlier state",
+			gitapi.ListReviewsResponse{newReview("octocat", "APPROVED"), newReview("octocat", "COMMENTED")},
+			map[string]string{"octocat": "APPROVED"},
+		},
+		{
+			"comment is kept without earlier state",
+			gitapi.ListReviewsResponse{newReview("octocat", "COMMENTED"), newReview("hubot", "PENDING")},
+			map[string]string{"octocat": "COMMENTED"},
+		},
+		{
+			"dismissed review replaces earlier state",
+			gitapi.ListReviewsResponse{newReview("octocat", "APPROVED"), newReview("octocat", "DISMISSED")},
+			map[string]string{"octocat": "DISMISSED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latestReviewStates(tt.reviews); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("latestReviewStates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchReviewRules(t *testing.T) {
+	approvedPr := gitapi.PullRequest{Reviews: gitapi.ListReviewsResponse{
+		newReview("octocat", "APPROVED"),
+		newReview("hubot", "COMMENTED"),
+		newReview("monalisa", "CHANGES_REQUESTED"),
+		newReview("monalisa", "APPROVED"),
+	}}
+	changesPr := gitapi.PullRequest{Reviews: gitapi.ListReviewsResponse{
+		newReview("octocat", "APPROVED"),
+		newReview("monalisa", "CHANGES_REQUESTED"),
+		newReview("monalisa", "COMMENTED"),
+	}}
+	commentedPr := gitapi.PullRequest{Reviews: gitapi.ListReviewsResponse{
+		newReview("hubot", "COMMENTED"),
+	}}
+	noReviewPr := gitapi.PullRequest{}
+	two := 2
+	tests := []struct {
+		name  string
+		pr    gitapi.PullRequest
+		rules config.RuleTypeReview
+		want  bool
+	}{
+		{"empty rule passes", noReviewPr, config.RuleTypeReview{}, true},
+		{"approved state", approvedPr, config.RuleTypeReview{State: config.ReviewStates{"approved"}}, true},
+		{"changes requested state", changesPr, config.RuleTypeReview{State: config.ReviewStates{"changes-requested"}}, true},
+		{"changes requested is not approved", changesPr, config.RuleTypeReview{State: config.ReviewStates{"approved"}}, false},
+		{"commented state", commentedPr, config.RuleTypeReview{State: config.ReviewStates{"commented"}}, true},
+		{"none state", noReviewPr, config.RuleTypeReview{State: config.ReviewStates{"none", "commented"}}, true},
+		{"approval count passes", approvedPr, config.RuleTypeReview{Approvals: config.RuleTypeCount{Gte: &two}}, true},
+		{"approval count fails", changesPr, config.RuleTypeReview{Approvals: config.RuleTypeCount{Gte: &two}}, false},
+		{"approved by passes", approvedPr, config.RuleTypeReview{ApprovedBy: config.StringList{"someone", "monalisa"}}, true},
+		{"approved by fails", approvedPr, config.RuleTypeReview{ApprovedBy: config.StringList{"hubot"}}, false},
+		{"changes requested by passes", changesPr, config.RuleTypeReview{ChangesRequestedBy: config.StringList{"monalisa"}}, true},
+		{"changes requested by fails", approvedPr, config.RuleTypeReview{ChangesRequestedBy: config.StringList{"monalisa"}}, false},
+		{"reviewed by passes with comment", commentedPr, config.RuleTypeReview{ReviewedBy: config.StringList{"hubot"}}, true},
+		{"reviewed by fails", noReviewPr, config.RuleTypeReview{ReviewedBy: config.StringList{"hubot"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{ReviewRules: tt.rules}
+			got, err := r.MatchReviewRules(tt.pr)
+			if err != nil {
+				t.Errorf("Rule.MatchReviewRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchReviewRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,198 +1,558 @@
 package labeler
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
 	"strconv"
 	"sync"
 	"time"
 
-	"github.com/tanmancan/label-it/v1/internal/common"
 	"github.com/tanmancan/label-it/v1/internal/config"
 	"github.com/tanmancan/label-it/v1/internal/gitapi"
 )
 
 // Rule label name and rules from YAML config
 type Rule struct {
-	Label        string
-	HeadRules    config.RuleTypeString
-	BaseRules    config.RuleTypeString
-	TitleRules   config.RuleTypeString
-	BodyRules    config.RuleTypeString
-	UserRules    config.RuleTypeString
-	NumberRules  config.RuleTypeInt
-	FileRules    config.RuleTypeString
-	CreatedRules config.RuleTypeDate
-	UpdatedRules config.RuleTypeDate
+	Label               string
+	RemoveWhenUnmatched bool
+	HeadRules           config.RuleTypeString
+	BaseRules           config.RuleTypeString
+	TitleRules          config.RuleTypeString
+	BodyRules           config.RuleTypeString
+	UserRules           config.RuleTypeString
+	NumberRules         config.RuleTypeInt
+	FileRules           config.RuleTypeFile
+	SizeRules           config.RuleTypeSize
+	DraftRules          *bool
+	LabelsRules         config.RuleTypeList
+	ReviewerRules       config.RuleTypeList
+	AssigneeRules       config.RuleTypeList
+	ReviewRules         config.RuleTypeReview
+	ChecksRules         config.RuleTypeChecks
+	CommitRules         config.RuleTypeCommit
+	CommentRules        config.RuleTypeComment
+	MilestoneRules      config.RuleTypeList
+	AssociationRules    config.RuleTypeString
+	MergeableRules      config.RuleTypeMergeable
+	TeamRules           config.RuleTypeTeam
+	CodeOwnersRules     config.RuleTypeList
+	IssueRules          config.RuleTypeIssue
+	InheritLabels       config.RuleTypeString
+	CreatedRules        config.RuleTypeDate
+	UpdatedRules        config.RuleTypeDate
+	AllRules            []Rule
+	AnyRules            []Rule
+	NotRules            *Rule
+	// Owners that create the label, for rules expanded from a label template
+	templateOwners []string
+	// Set for rules expanded from inherit-labels
+	inherited bool
 }
 
 // LabelRules set of rules created from YAML config
 type LabelRules []Rule
 
-// Reusable function for pattern match
-func matchString(pattern string, s string) bool {
+// Compiled regex patterns, shared by every pull request
+var compiledPatterns sync.Map
+
+// Reusable function for pattern match. Patterns are validated when the config
+// is loaded, and compiled once
+func matchString(pattern string, s string) (bool, error) {
+	if exp, found := compiledPatterns.Load(pattern); found == true {
+		return exp.(*regexp.Regexp).MatchString(s), nil
+	}
+
 	exp, experr := regexp.Compile(pattern)
-	common.CheckErr(experr)
+	if experr != nil {
+		return false, experr
+	}
+	compiledPatterns.Store(pattern, exp)
+
+	return exp.MatchString(s), nil
+}
+
+// Checks if any pattern in a list matches a string value
+func matchAny(patterns config.StringList, s string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := matchString(pattern, s)
+		if err != nil || matched == true {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+// Checks regex match and no-match patterns against a string value.
+// Match passes if any pattern matches, no-match fails if any pattern matches
+// Returns true if both checks validate, otherwise returns false
+func matchPatterns(match config.StringList, noMatch config.StringList, s string) (bool, error) {
+	if len(match) > 0 {
+		matched, err := matchAny(match, s)
+		if err != nil || matched != true {
+			return false, err
+		}
+	}
+
+	if len(noMatch) > 0 {
+		matched, err := matchAny(noMatch, s)
+		if err != nil || matched == true {
+			return false, err
+		}
+	}
 
-	return exp.MatchString(s)
+	return true, nil
 }
 
 // RuleTypeStringValidator validates a string value using rule group string
+// Exact passes if the value equals any rule value, no-exact fails if it equals any rule value
 // Returns true if all rules validate, otherwise returns false
-func RuleTypeStringValidator(r config.RuleTypeString, s string) bool {
+func RuleTypeStringValidator(r config.RuleTypeString, s string) (bool, error) {
 	exact := r.Exact
 	noExact := r.NoExact
-	match := r.Match
-	noMatch := r.NoMatch
 
 	switch {
-	case exact != "" && exact != s,
-		noExact != "" && noExact == s,
-		match != "" && matchString(match, s) != true,
-		noMatch != "" && matchString(noMatch, s) == true:
-		return false
+	case len(exact) > 0 && exact.Contains(s) != true,
+		noExact.Contains(s) == true:
+		return false, nil
 	}
 
-	return true
+	return matchPatterns(r.Match, r.NoMatch, s)
 }
 
 // RuleTypeIntValidator validates a int value using rule group integer
+// Exact passes if the value equals any rule value, no-exact fails if it equals any rule value
 // Returns true if all rules validate, otherwise returns false
-func RuleTypeIntValidator(r config.RuleTypeInt, i int) bool {
+func RuleTypeIntValidator(r config.RuleTypeInt, i int) (bool, error) {
 	exact := r.Exact
 	noExact := r.NoExact
-	match := r.Match
-	noMatch := r.NoMatch
+
+	switch {
+	case len(exact) > 0 && exact.Contains(i) != true,
+		noExact.Contains(i) == true:
+		return false, nil
+	}
 
 	// For regex pattern match, we coerce the int value into a string
 	// @TODO: maybe just store the pr number as string and skip this step
 	s := strconv.Itoa(i)
 
-	switch {
-	case exact != 0 && exact != i,
-		noExact != 0 && noExact == i,
-		match != "" && matchString(match, s) != true,
-		noMatch != "" && matchString(noMatch, s) == true:
+	return matchPatterns(r.Match, r.NoMatch, s)
+}
+
+// RuleTypeListValidator validates a list of string values using rule group list.
+// Exact passes if any rule value is found in the list, no-exact fails if any rule value is found.
+// Match passes if any pattern matches a value, no-match fails if any pattern matches a value.
+// Empty checks if the list has no values.
+// Returns true if all rules validate, otherwise returns false
+func RuleTypeListValidator(r config.RuleTypeList, values []string) (bool, error) {
+	if r.Empty != nil && (len(values) == 0) != *r.Empty {
+		return false, nil
+	}
+
+	contains := func(list config.StringList) bool {
+		for _, value := range values {
+			if list.Contains(value) == true {
+				return true
+			}
+		}
 		return false
 	}
 
-	return true
+	switch {
+	case len(r.Exact) > 0 && contains(r.Exact) == false,
+		contains(r.NoExact) == true:
+		return false, nil
+	}
+
+	if len(r.Match) > 0 {
+		matched, err := matchAnyValue(values, r.Match)
+		if err != nil || matched == false {
+			return false, err
+		}
+	}
+
+	if len(r.NoMatch) > 0 {
+		matched, err := matchAnyValue(values, r.NoMatch)
+		if err != nil || matched == true {
+			return false, err
+		}
+	}
+
+	return true, nil
 }
 
 // MatchHeadRules determines if provided pull request head branch matche the HeadRule
-func (r Rule) MatchHeadRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchHeadRules(pr gitapi.PullRequest) (bool, error) {
 	return RuleTypeStringValidator(r.HeadRules, pr.Head.Ref)
 }
 
 // MatchBaseRules determines if provided pull request base branch matche theBaseRule
-func (r Rule) MatchBaseRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchBaseRules(pr gitapi.PullRequest) (bool, error) {
 	return RuleTypeStringValidator(r.BaseRules, pr.Base.Ref)
 }
 
 // MatchTitleRules determines if provided pull request contains text in title rules
-func (r Rule) MatchTitleRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchTitleRules(pr gitapi.PullRequest) (bool, error) {
 	return RuleTypeStringValidator(r.TitleRules, pr.Title)
 }
 
 // MatchBodyRules determines if provided pull request contains text in title rules
-func (r Rule) MatchBodyRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchBodyRules(pr gitapi.PullRequest) (bool, error) {
 	return RuleTypeStringValidator(r.BodyRules, pr.Body)
 }
 
 // MatchUserRules checks if pull request creator username matches user rule
-func (r Rule) MatchUserRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchUserRules(pr gitapi.PullRequest) (bool, error) {
 	return RuleTypeStringValidator(r.UserRules, pr.User.Login)
 }
 
 // MatchNumberRules determines if pull request issue number matches provider number in rule
-func (r Rule) MatchNumberRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchNumberRules(pr gitapi.PullRequest) (bool, error) {
 	return RuleTypeIntValidator(r.NumberRules, pr.Number)
 }
 
+// Checks if a sorted list of file paths contains any of the given paths
+func containsAnyFile(files []string, paths config.StringList) bool {
+	for _, path := range paths {
+		idx := sort.SearchStrings(files, path)
+
+		// Exact match found
+		if (idx != len(files)) && (files[idx] == path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Checks if any regex pattern matches any value, such as a list of file paths
+func matchAnyValue(values []string, patterns config.StringList) (bool, error) {
+	for _, value := range values {
+		matched, err := matchAny(patterns, value)
+		if err != nil || matched == true {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+// Checks if the number of values passing a test satisfies the quantifier.
+// The test is called with the index of each value, from 0 to total.
+// Any requires at least one value to pass, all requires every value to pass
+// and none requires no value to pass. When minCount is provided, any and all
+// also require at least minCount values to pass
+func matchQuantifier(quantifier config.Quantifier, minCount int, total int, test func(int) (bool, error)) (bool, error) {
+	count := 0
+	for i := 0; i < total; i++ {
+		passed, err := test(i)
+		if err != nil {
+			return false, err
+		}
+		if passed == true {
+			count++
+		}
+	}
+
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	switch quantifier {
+	case config.QuantifierAll:
+		return count == total && count >= minCount, nil
+	case config.QuantifierNone:
+		return count == 0, nil
+	default:
+		return count >= minCount, nil
+	}
+}
+
+// Returns the paths of each changed file checked by a file rule. Files are
+// limited to the statuses in the rule, and renamed files include their
+// previous path when the rule checks previous file names
+func fileRulePaths(rule config.RuleTypeFile, pr gitapi.PullRequest) [][]string {
+	paths := [][]string{}
+	if len(rule.Status) == 0 && rule.PreviousFilename == false {
+		for _, file := range pr.Files {
+			paths = append(paths, []string{file})
+		}
+		return paths
+	}
+
+	for _, file := range pr.FileDetails {
+		if len(rule.Status) > 0 && rule.Status.Contains(file.Status) == false {
+			continue
+		}
+
+		filePaths := []string{file.Filename}
+		if rule.PreviousFilename == true && file.PreviousFilename != "" {
+			filePaths = append(filePaths, file.PreviousFilename)
+		}
+		paths = append(paths, filePaths)
+	}
+
+	return paths
+}
+
 // MatchFileRules determines if changed files in pull request matches provided file rule
-func (r Rule) MatchFileRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchFileRules(pr gitapi.PullRequest) (bool, error) {
 	rule := r.FileRules
-	if rule == struct {
-		Exact   string `yaml:"exact,omitempty"`
-		NoExact string `yaml:"no-exact,omitempty"`
-		Match   string `yaml:"match,omitempty"`
-		NoMatch string `yaml:"no-match,omitempty"`
-	}{} {
-		return true
+	if rule.IsEmpty() {
+		return true, nil
 	}
 
-	files := pr.Files
-	valid := true
-	invalid := false
-	exact := valid
-	noExact := valid
-	match := valid
-	noMatch := valid
+	filePaths := fileRulePaths(rule, pr)
+	files := []string{}
+	for _, paths := range filePaths {
+		files = append(files, paths...)
+	}
+	sort.Strings(files)
 
-	if rule.NoExact != "" {
-		// No exact start as valid
-		// But if a exact file is found, it will be marked invalid
-		noExact = valid
-		noExactIdx := sort.SearchStrings(files, rule.NoExact)
+	// If any no exact path is found in the changed files,
+	// the no exact check is invalid
+	if containsAnyFile(files, rule.NoExact) == true {
+		return false, nil
+	}
 
-		// Exact match found
-		if (noExactIdx != len(files)) && (files[noExactIdx] == rule.NoExact) {
-			return false
+	// If any no match pattern matches a changed file,
+	// the no match check is invalid
+	if len(rule.NoMatch) > 0 {
+		matched, err := matchAnyValue(files, rule.NoMatch)
+		if err != nil || matched == true {
+			return false, err
 		}
 	}
 
-	if rule.NoMatch != "" {
-		// No match start as valid.
-		// If a match is found using the no match check,
-		// then no match will be marked as invalid
-		noMatch = valid
-		for _, file := range files {
-			if matchString(rule.NoMatch, file) == true {
-				noMatch = invalid
-				break
-			}
+	// If any no glob pattern matches a changed file,
+	// the no glob check is invalid
+	if len(rule.NoGlob) > 0 {
+		matched, err := globAnyFile(files, rule.NoGlob)
+		if err != nil || matched == true {
+			return false, err
 		}
-		if noMatch == invalid {
-			return false
+	}
+
+	// Exact, match and glob checks test each changed file. The quantifier
+	// determines how many of the changed files must pass each check
+	checks := []func(string) (bool, error){}
+
+	if len(rule.Exact) > 0 {
+		checks = append(checks, func(file string) (bool, error) {
+			return rule.Exact.Contains(file), nil
+		})
+	}
+
+	if len(rule.Match) > 0 {
+		checks = append(checks, func(file string) (bool, error) {
+			return matchAny(rule.Match, file)
+		})
+	}
+
+	if len(rule.Glob) > 0 {
+		checks = append(checks, func(file string) (bool, error) {
+			return globAny(rule.Glob, file)
+		})
+	}
+
+	// A status without other checks only requires files with the status
+	if len(checks) == 0 && len(rule.Status) > 0 {
+		checks = append(checks, func(file string) (bool, error) {
+			return true, nil
+		})
+	}
+
+	// A renamed file passes a check if its current or previous path passes
+	for _, check := range checks {
+		matched, err := matchQuantifier(rule.Quantifier, rule.MinCount, len(filePaths), func(i int) (bool, error) {
+			for _, path := range filePaths[i] {
+				passed, err := check(path)
+				if err != nil || passed == true {
+					return passed, err
+				}
+			}
+			return false, nil
+		})
+		if err != nil || matched == false {
+			return false, err
 		}
 	}
 
-	if rule.Exact != "" {
-		// If exact rule is provided, we set default as invalid.
-		// It will only pass if a match has been found
-		exact = invalid
-		exactIdx := sort.SearchStrings(files, rule.Exact)
+	return true, nil
+}
+
+// MatchLabelsRules checks if the labels on the pull request match the labels rule.
+// Includes labels added or removed by rules earlier in the config
+func (r Rule) MatchLabelsRules(pr gitapi.PullRequest) (bool, error) {
+	var labels []string
+	for _, prLabel := range pr.Labels {
+		labels = append(labels, prLabel.Name)
+	}
+
+	return RuleTypeListValidator(r.LabelsRules, labels)
+}
+
+// MatchReviewerRules checks if the requested reviewers on the pull request match the
+// reviewer rule. Requested teams are compared using the "owner/team-slug" format
+func (r Rule) MatchReviewerRules(pr gitapi.PullRequest) (bool, error) {
+	var reviewers []string
+	for _, user := range pr.Reviewers {
+		reviewers = append(reviewers, user.Login)
+	}
+
+	for _, team := range pr.Teams {
+		reviewers = append(reviewers, fmt.Sprintf("%[1]s/%[2]s", config.YamlConfig.Owner, team.Slug))
+	}
+
+	return RuleTypeListValidator(r.ReviewerRules, reviewers)
+}
+
+// MatchAssigneeRules checks if the assignees of the pull request match the assignee rule
+func (r Rule) MatchAssigneeRules(pr gitapi.PullRequest) (bool, error) {
+	var assignees []string
+	for _, user := range pr.Assignees {
+		assignees = append(assignees, user.Login)
+	}
+
+	return RuleTypeListValidator(r.AssigneeRules, assignees)
+}
+
+// MatchMilestoneRules checks if the milestone title of the pull request matches the milestone rule
+func (r Rule) MatchMilestoneRules(pr gitapi.PullRequest) (bool, error) {
+	var milestones []string
+	if pr.Milestone != nil {
+		milestones = append(milestones, pr.Milestone.Title)
+	}
+
+	return RuleTypeListValidator(r.MilestoneRules, milestones)
+}
+
+// MatchAssociationRules checks if the author association of the pull request matches the association rule
+func (r Rule) MatchAssociationRules(pr gitapi.PullRequest) (bool, error) {
+	return RuleTypeStringValidator(r.AssociationRules, pr.Association)
+}
+
+// MatchMergeableRules checks if the mergeable state of the pull request matches the mergeable rule.
+// If Github has not computed the mergeable state, the conflicts check does not pass
+func (r Rule) MatchMergeableRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.MergeableRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	if rule.Conflicts != nil && (pr.Mergeable == nil || *pr.Mergeable == *rule.Conflicts) {
+		return false, nil
+	}
+
+	if len(rule.State) > 0 && rule.State.Contains(pr.MergeableState) == false {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Checks team membership using the Github API
+var isTeamMember = gitapi.IsTeamMember
 
-		// No exact match found
-		if exactIdx == len(files) {
-			exact = invalid
+// Checks if a user is a member of any of the teams
+func memberOfAnyTeam(teams config.StringList, login string) (bool, error) {
+	for _, team := range teams {
+		member, err := isTeamMember(team, login)
+		if err != nil || member == true {
+			return member, err
 		}
+	}
 
-		// Exact match found
-		if (exactIdx != len(files)) && (files[exactIdx] == rule.Exact) {
-			exact = valid
+	return false, nil
+}
+
+// MatchTeamRules checks if the pull request creator is a member of the teams in the team rule.
+// Team members are requested the first time a team is checked, and cached for the run
+func (r Rule) MatchTeamRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.TeamRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	if len(rule.NoMember) > 0 {
+		member, err := memberOfAnyTeam(rule.NoMember, pr.User.Login)
+		if err != nil || member == true {
+			return false, err
 		}
 	}
 
-	if rule.Match != "" {
-		// If match check is provided,
-		// we start it as invalid.
-		// Match will only be flagged valid if a match is found.
-		match = invalid
-		for _, file := range files {
-			if matchString(rule.Match, file) == true {
-				match = valid
-				break
+	if len(rule.Member) > 0 {
+		return memberOfAnyTeam(rule.Member, pr.User.Login)
+	}
+
+	return true, nil
+}
+
+// MatchDraftRules checks if pull request draft status matches the draft rule
+func (r Rule) MatchDraftRules(pr gitapi.PullRequest) (bool, error) {
+	if r.DraftRules == nil {
+		return true, nil
+	}
+
+	return pr.Draft == *r.DraftRules, nil
+}
+
+// RuleTypeCountValidator validates a numeric value against the gt, gte, lt and lte checks
+// Returns true if all checks validate, otherwise returns false
+func RuleTypeCountValidator(r config.RuleTypeCount, n int) bool {
+	switch {
+	case r.Gt != nil && n <= *r.Gt,
+		r.Gte != nil && n < *r.Gte,
+		r.Lt != nil && n >= *r.Lt,
+		r.Lte != nil && n > *r.Lte:
+		return false
+	}
+
+	return true
+}
+
+// MatchSizeRules determines if the number of changed lines and files in a pull
+// request matches the size rule. Changed files matching an exclude pattern
+// are not counted
+func (r Rule) MatchSizeRules(pr gitapi.PullRequest) (bool, error) {
+	rule := r.SizeRules
+	if rule.IsEmpty() {
+		return true, nil
+	}
+
+	additions := pr.Additions
+	deletions := pr.Deletions
+	files := pr.ChangedFiles
+
+	if len(rule.Exclude) > 0 {
+		additions, deletions, files = 0, 0, 0
+		for _, file := range pr.FileDetails {
+			excluded, err := globAny(rule.Exclude, file.Filename)
+			if err != nil {
+				return false, err
 			}
+			if excluded == true {
+				continue
+			}
+			additions += file.Additions
+			deletions += file.Deletions
+			files++
 		}
 	}
 
-	return exact && noExact && match && noMatch
+	matched := RuleTypeCountValidator(rule.Additions, additions) &&
+		RuleTypeCountValidator(rule.Deletions, deletions) &&
+		RuleTypeCountValidator(rule.Total, additions+deletions) &&
+		RuleTypeCountValidator(rule.Files, files)
+
+	return matched, nil
 }
 
 // MatchDateRules determines if pull request date value is
 // given number of days in the past
-func (r Rule) MatchDateRules(pr gitapi.PullRequest) bool {
+func (r Rule) MatchDateRules(pr gitapi.PullRequest) (bool, error) {
 	createdRule := r.CreatedRules
 	updatedRule := r.UpdatedRules
 
@@ -201,10 +561,12 @@ func (r Rule) MatchDateRules(pr gitapi.PullRequest) bool {
 	}{} {
 		timeUpdatedRule := time.Now().AddDate(0, 0, -1*updatedRule.DaysBefore)
 		prUpdatedDate, updateParseErr := time.Parse(time.RFC3339, pr.UpdatedAt)
-		common.CheckErr(updateParseErr)
+		if updateParseErr != nil {
+			return false, updateParseErr
+		}
 
 		if !prUpdatedDate.Before(timeUpdatedRule) {
-			return false
+			return false, nil
 		}
 	}
 
@@ -213,38 +575,285 @@ func (r Rule) MatchDateRules(pr gitapi.PullRequest) bool {
 	}{} {
 		timeCreatedRule := time.Now().AddDate(0, 0, -1*createdRule.DaysBefore)
 		prCreatedDate, createParseErr := time.Parse(time.RFC3339, pr.CreatedAt)
-		common.CheckErr(createParseErr)
+		if createParseErr != nil {
+			return false, createParseErr
+		}
 
 		if !prCreatedDate.Before(timeCreatedRule) {
-			return false
+			return false, nil
 		}
 	}
 
-	return true
+	return true, nil
+}
+
+// MatchConditionRules walks nested condition blocks. All blocks must
+// match, at least one any block must match, and the not block must not match
+func (r Rule) MatchConditionRules(pr gitapi.PullRequest) (bool, error) {
+	for _, allRule := range r.AllRules {
+		matched, err := allRule.MatchAllRules(pr)
+		if err != nil || matched != true {
+			return false, err
+		}
+	}
+
+	if len(r.AnyRules) > 0 {
+		anyMatched := false
+		for _, anyRule := range r.AnyRules {
+			matched, err := anyRule.MatchAllRules(pr)
+			if err != nil {
+				return false, err
+			}
+			if matched == true {
+				anyMatched = true
+				break
+			}
+		}
+		if anyMatched == false {
+			return false, nil
+		}
+	}
+
+	if r.NotRules != nil {
+		matched, err := r.NotRules.MatchAllRules(pr)
+		if err != nil || matched == true {
+			return false, err
+		}
+	}
+
+	return true, nil
 }
 
 // MatchAllRules checks if a pull request passes all checks for a given rule
-func (r Rule) MatchAllRules(pr gitapi.PullRequest) bool {
-	switch {
-	case r.MatchDateRules(pr) != true:
-		return false
-	case r.MatchHeadRules(pr) != true:
-		return false
-	case r.MatchBaseRules(pr) != true:
-		return false
-	case r.MatchTitleRules(pr) != true:
-		return false
-	case r.MatchBodyRules(pr) != true:
-		return false
-	case r.MatchUserRules(pr) != true:
-		return false
-	case r.MatchNumberRules(pr) != true:
-		return false
-	case r.MatchFileRules(pr) != true:
-		return false
+func (r Rule) MatchAllRules(pr gitapi.PullRequest) (bool, error) {
+	matchers := []func(gitapi.PullRequest) (bool, error){
+		r.MatchDateRules,
+		r.MatchDraftRules,
+		r.MatchLabelsRules,
+		r.MatchReviewerRules,
+		r.MatchAssigneeRules,
+		r.MatchHeadRules,
+		r.MatchBaseRules,
+		r.MatchTitleRules,
+		r.MatchBodyRules,
+		r.MatchUserRules,
+		r.MatchNumberRules,
+		r.MatchFileRules,
+		r.MatchSizeRules,
+		r.MatchReviewRules,
+		r.MatchChecksRules,
+		r.MatchCommitRules,
+		r.MatchCommentRules,
+		r.MatchMilestoneRules,
+		r.MatchAssociationRules,
+		r.MatchMergeableRules,
+		r.MatchTeamRules,
+		r.MatchCodeOwnersRules,
+		r.MatchIssueRules,
+		r.MatchConditionRules,
 	}
 
-	return true
+	for _, matcher := range matchers {
+		matched, err := matcher(pr)
+		if err != nil || matched != true {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Additional pull request data that must be fetched before rules are checked
+type prRequirements struct {
+	files     bool
+	details   bool
+	mergeable bool
+	reviews   bool
+	checks    bool
+	commits   bool
+	comments  bool
+	owners    bool
+	issues    bool
+}
+
+// Combines the data required by two sets of requirements
+func (req prRequirements) merge(other prRequirements) prRequirements {
+	return prRequirements{
+		files:     req.files || other.files,
+		details:   req.details || other.details,
+		mergeable: req.mergeable || other.mergeable,
+		reviews:   req.reviews || other.reviews,
+		checks:    req.checks || other.checks,
+		commits:   req.commits || other.commits,
+		comments:  req.comments || other.comments,
+		owners:    req.owners || other.owners,
+		issues:    req.issues || other.issues,
+	}
+}
+
+// Returns the data required by the rule, including nested condition blocks.
+// Size rules with exclude patterns are counted from the changed files,
+// otherwise the totals from the pull request details are used.
+// Code owners are found using the changed files
+func (r Rule) requirements() prRequirements {
+	req := prRequirements{
+		files:     r.FileRules.IsEmpty() == false,
+		details:   r.SizeRules.IsEmpty() == false && len(r.SizeRules.Exclude) == 0,
+		mergeable: r.MergeableRules.IsEmpty() == false,
+		reviews:   r.ReviewRules.IsEmpty() == false,
+		checks:    r.ChecksRules.IsEmpty() == false,
+		commits:   r.CommitRules.IsEmpty() == false,
+		comments:  r.CommentRules.IsEmpty() == false,
+		owners:    r.CodeOwnersRules.IsEmpty() == false || isLabelTemplate(r.Label),
+		issues:    r.IssueRules.IsEmpty() == false || r.InheritLabels.IsEmpty() == false,
+	}
+
+	if (r.SizeRules.IsEmpty() == false && len(r.SizeRules.Exclude) > 0) || req.owners == true {
+		req.files = true
+	}
+
+	for _, allRule := range r.AllRules {
+		req = req.merge(allRule.requirements())
+	}
+
+	for _, anyRule := range r.AnyRules {
+		req = req.merge(anyRule.requirements())
+	}
+
+	if r.NotRules != nil {
+		req = req.merge(r.NotRules.requirements())
+	}
+
+	return req
+}
+
+// Fetches the additional pull request data required by the rules
+func fetchRequirements(req prRequirements, pr gitapi.PullRequest) (gitapi.PullRequest, error) {
+	if req.files == true {
+		files, err := gitapi.ListAllFiles(pr.Number)
+		if err != nil {
+			return pr, err
+		}
+		pr.FileDetails = files
+		pr.Files = files.Filenames()
+	}
+
+	if req.details == true || req.mergeable == true {
+		getPull := gitapi.GetPull
+		if req.mergeable == true {
+			getPull = gitapi.GetMergeablePull
+		}
+
+		details, err := getPull(pr.Number)
+		if err != nil {
+			return pr, err
+		}
+		pr.Additions = details.Additions
+		pr.Deletions = details.Deletions
+		pr.ChangedFiles = details.ChangedFiles
+		pr.Mergeable = details.Mergeable
+		pr.MergeableState = details.MergeableState
+	}
+
+	if req.reviews == true {
+		reviews, err := gitapi.ListReviews(pr.Number)
+		if err != nil {
+			return pr, err
+		}
+		pr.Reviews = reviews
+	}
+
+	if req.checks == true {
+		checks, err := gitapi.ListChecks(pr.Head.SHA)
+		if err != nil {
+			return pr, err
+		}
+		pr.Checks = checks
+	}
+
+	if req.owners == true {
+		entries, err := codeOwners.get(pr.Base.Ref)
+		if err != nil {
+			return pr, err
+		}
+
+		owners, err := changedFileOwners(entries, pr.Files)
+		if err != nil {
+			return pr, err
+		}
+		pr.CodeOwners = owners
+	}
+
+	if req.commits == true {
+		commits, err := gitapi.ListCommits(pr.Number)
+		if err != nil {
+			return pr, err
+		}
+		pr.Commits = commits
+	}
+
+	if req.comments == true {
+		comments, err := gitapi.ListComments(pr.Number)
+		if err != nil {
+			return pr, err
+		}
+		pr.Comments = comments
+	}
+
+	if req.issues == true {
+		issues, err := linkedIssues(pr)
+		if err != nil {
+			return pr, err
+		}
+		pr.LinkedIssues = issues
+	}
+
+	return pr, nil
+}
+
+// Creates a rule from a YAML rule set, including nested condition blocks
+func newRule(ruleSet config.YamlRuleSet) Rule {
+	rule := Rule{
+		HeadRules:        ruleSet.Head,
+		BaseRules:        ruleSet.Base,
+		TitleRules:       ruleSet.Title,
+		BodyRules:        ruleSet.Body,
+		UserRules:        ruleSet.User,
+		NumberRules:      ruleSet.Number,
+		FileRules:        ruleSet.File,
+		SizeRules:        ruleSet.Size,
+		DraftRules:       ruleSet.Draft,
+		LabelsRules:      ruleSet.Labels,
+		ReviewerRules:    ruleSet.Reviewer,
+		AssigneeRules:    ruleSet.Assignee,
+		ReviewRules:      ruleSet.Review,
+		ChecksRules:      ruleSet.Checks,
+		CommitRules:      ruleSet.Commit,
+		CommentRules:     ruleSet.Comment,
+		MilestoneRules:   ruleSet.Milestone,
+		AssociationRules: ruleSet.Association,
+		MergeableRules:   ruleSet.Mergeable,
+		TeamRules:        ruleSet.Team,
+		CodeOwnersRules:  ruleSet.CodeOwners,
+		IssueRules:       ruleSet.Issue,
+		CreatedRules:     ruleSet.Created,
+		UpdatedRules:     ruleSet.Updated,
+	}
+
+	for _, allSet := range ruleSet.All {
+		rule.AllRules = append(rule.AllRules, newRule(allSet))
+	}
+
+	for _, anySet := range ruleSet.Any {
+		rule.AnyRules = append(rule.AnyRules, newRule(anySet))
+	}
+
+	if ruleSet.Not != nil {
+		notRule := newRule(*ruleSet.Not)
+		rule.NotRules = &notRule
+	}
+
+	return rule
 }
 
 // Checks if pull request already has label
@@ -269,69 +878,152 @@ func prHasLabel(pr gitapi.PullRequest, label string) bool {
 	return existingLabels[searchIdx] == label
 }
 
+// Returns a copy of the pull request labels with a label added or removed.
+// Used so rules can check labels changed by rules earlier in the config
+func updateLabelList(labels []gitapi.PrIssueLabel, label string, add bool) []gitapi.PrIssueLabel {
+	updated := []gitapi.PrIssueLabel{}
+	for _, prLabel := range labels {
+		if prLabel.Name != label {
+			updated = append(updated, prLabel)
+		}
+	}
+
+	if add == true {
+		updated = append(updated, gitapi.PrIssueLabel{Name: label})
+	}
+
+	return updated
+}
+
+// Groups rules by label, in the order each label first appears. Groups
+// that share a label are combined, so the label matches if any group matches
+func groupRulesByLabel(rules LabelRules) []LabelRules {
+	groups := []LabelRules{}
+	index := map[string]int{}
+	for _, r := range rules {
+		i, found := index[r.Label]
+		if found == false {
+			i = len(groups)
+			index[r.Label] = i
+			groups = append(groups, LabelRules{})
+		}
+		groups[i] = append(groups[i], r)
+	}
+
+	return groups
+}
+
+// Result of checking an individual pull request against all rules
+type prResult struct {
+	prLabel gitapi.PrLabel
+	err     error
+}
+
 // Checks a pull request for all provided rules
-func checkPr(hasFileRule bool, pr gitapi.PullRequest, labelRules LabelRules, c chan gitapi.PrLabel, wg *sync.WaitGroup) {
-	// Pre fetch files if file rule is present
-	if hasFileRule == true {
-		pr.Files = gitapi.GetAllFiles(pr.Number)
+func checkPr(req prRequirements, pr gitapi.PullRequest, labelRules LabelRules, c chan prResult, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Pre fetch data required by the rules
+	pr, err := fetchRequirements(req, pr)
+	if err != nil {
+		c <- prResult{gitapi.PrLabel{Issue: pr.Number}, err}
+		return
 	}
 
-	newLabels := []string{}
+	// Rules with a label template or inherit-labels are expanded into a rule for each label
+	prRules := LabelRules{}
 	for _, r := range labelRules {
-		hasLabel := prHasLabel(pr, r.Label)
+		expand := expandLabelTemplate
+		switch {
+		case r.InheritLabels.IsEmpty() == false:
+			expand = expandInheritLabels
+		case isLabelTemplate(r.Label) == false:
+			prRules = append(prRules, r)
+			continue
+		}
+
+		expanded, err := expand(r, pr)
+		if err != nil {
+			c <- prResult{gitapi.PrLabel{Issue: pr.Number}, fmt.Errorf("label \"%[1]s\": %[2]w", r.Label, err)}
+			return
+		}
+		prRules = append(prRules, expanded...)
+	}
 
-		if hasLabel == false {
-			matchAll := r.MatchAllRules(pr)
+	// Labels are checked in config order. All groups for a label are checked where
+	// the label first appears, and labels added or removed are visible to the labels
+	// rule of any rule that follows it. A label is only removed if no group matches,
+	// and any group for the label sets remove-when-unmatched
+	newLabels := []string{}
+	removeLabels := []string{}
+	for _, group := range groupRulesByLabel(prRules) {
+		label := group[0].Label
+		hasLabel := prHasLabel(pr, label)
+
+		removable := false
+		for _, r := range group {
+			removable = removable || r.RemoveWhenUnmatched
+		}
+
+		// Existing labels only need to be checked if they may be removed
+		if hasLabel == true && removable == false {
+			continue
+		}
+
+		matched := false
+		for _, r := range group {
+			matchAll, err := r.MatchAllRules(pr)
+			if err != nil {
+				c <- prResult{gitapi.PrLabel{Issue: pr.Number}, fmt.Errorf("label \"%[1]s\": %[2]w", label, err)}
+				return
+			}
 			if matchAll == true {
-				newLabels = append(newLabels, r.Label)
+				matched = true
+				break
 			}
 		}
-	}
 
-	if len(newLabels) != 0 {
-		c <- gitapi.PrLabel{Issue: pr.Number, Labels: newLabels}
+		switch {
+		case hasLabel == false && matched == true:
+			newLabels = append(newLabels, label)
+			pr.Labels = updateLabelList(pr.Labels, label, true)
+		case hasLabel == true && matched == false:
+			removeLabels = append(removeLabels, label)
+			pr.Labels = updateLabelList(pr.Labels, label, false)
+		}
 	}
 
-	wg.Done()
+	if len(newLabels) != 0 || len(removeLabels) != 0 {
+		c <- prResult{gitapi.PrLabel{Issue: pr.Number, Labels: newLabels, Remove: removeLabels}, nil}
+	}
 }
 
 // RuleParser parses rules and checks if they match provided pull requests
-// returns a list of matched pull request numbers and labels to apply to them
-func RuleParser(prList gitapi.ListPullsResponse) []gitapi.PrLabel {
+// returns a list of matched pull request numbers and labels to apply to them.
+// Pull requests that could not be checked are returned as PrErrors, while
+// the remaining pull requests continue to be processed
+func RuleParser(prList gitapi.ListPullsResponse) ([]gitapi.PrLabel, error) {
 	labelRules := LabelRules{}
-	hasFileRule := false
+	req := prRequirements{}
 
 	for _, rule := range config.YamlConfig.Rules {
-		newRule := Rule{
-			rule.Label,
-			rule.Head,
-			rule.Base,
-			rule.Title,
-			rule.Body,
-			rule.User,
-			rule.Number,
-			rule.File,
-			rule.Created,
-			rule.Updated,
-		}
-		labelRules = append(labelRules, newRule)
-		if rule.File != struct {
-			Exact   string `yaml:"exact,omitempty"`
-			NoExact string `yaml:"no-exact,omitempty"`
-			Match   string `yaml:"match,omitempty"`
-			NoMatch string `yaml:"no-match,omitempty"`
-		}{} {
-			hasFileRule = true
-		}
+		labelRule := newRule(rule.YamlRuleSet)
+		labelRule.Label = rule.Label
+		labelRule.RemoveWhenUnmatched = rule.RemoveWhenUnmatched
+		labelRule.InheritLabels = rule.InheritLabels
+
+		labelRules = append(labelRules, labelRule)
+		req = req.merge(labelRule.requirements())
 	}
 
 	matchedLabelPr := []gitapi.PrLabel{}
-	c := make(chan gitapi.PrLabel)
+	prErrors := PrErrors{}
+	c := make(chan prResult)
 	var wg sync.WaitGroup
 
 	for _, pr := range prList {
 		wg.Add(1)
-		go checkPr(hasFileRule, pr, labelRules, c, &wg)
+		go checkPr(req, pr, labelRules, c, &wg)
 	}
 
 	go func() {
@@ -339,9 +1031,18 @@ func RuleParser(prList gitapi.ListPullsResponse) []gitapi.PrLabel {
 		close(c)
 	}()
 
-	for newPrLabel := range c {
-		matchedLabelPr = append(matchedLabelPr, newPrLabel)
+	for result := range c {
+		if result.err != nil {
+			prErrors = append(prErrors, PrError{result.prLabel.Issue, result.err})
+			continue
+		}
+		matchedLabelPr = append(matchedLabelPr, result.prLabel)
+	}
+
+	if len(prErrors) > 0 {
+		prErrors.sort()
+		return matchedLabelPr, prErrors
 	}
 
-	return matchedLabelPr
+	return matchedLabelPr, nil
 }
@@ -1,6 +1,8 @@
 package labeler
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -22,10 +24,10 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"Passes all checks",
 			args{
 				config.RuleTypeString{
-					Exact:   "octopus hello",
-					NoExact: "world",
-					Match:   "^(octopus)",
-					NoMatch: "(lion)$",
+					Exact:   config.StringList{"octopus hello"},
+					NoExact: config.StringList{"world"},
+					Match:   config.StringList{"^(octopus)"},
+					NoMatch: config.StringList{"(lion)$"},
 				},
 				"octopus hello",
 			},
@@ -35,7 +37,7 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"passes exact check",
 			args{
 				config.RuleTypeString{
-					Exact: "LionOctopus",
+					Exact: config.StringList{"LionOctopus"},
 				},
 				"LionOctopus",
 			},
@@ -45,7 +47,7 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"does not pass exact check",
 			args{
 				config.RuleTypeString{
-					Exact: "TigerHippo",
+					Exact: config.StringList{"TigerHippo"},
 				},
 				"HippoTiger",
 			},
@@ -55,7 +57,7 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"passes no-exact check",
 			args{
 				config.RuleTypeString{
-					NoExact: "FishHead",
+					NoExact: config.StringList{"FishHead"},
 				},
 				"BirdWatch",
 			},
@@ -65,7 +67,7 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"does not pass no-exact check",
 			args{
 				config.RuleTypeString{
-					NoExact: "TreeSnake",
+					NoExact: config.StringList{"TreeSnake"},
 				},
 				"TreeSnake",
 			},
@@ -75,7 +77,7 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"passes match check",
 			args{
 				config.RuleTypeString{
-					Match: "\\w*ee",
+					Match: config.StringList{"\\w*ee"},
 				},
 				"One two three four five",
 			},
@@ -85,16 +87,70 @@ func TestRuleTypeStringValidator(t *testing.T) {
 			"does not pass no-match check",
 			args{
 				config.RuleTypeString{
-					NoMatch: "^(Tr)",
+					NoMatch: config.StringList{"^(Tr)"},
 				},
 				"TreeSnake",
 			},
 			false,
 		},
+		{
+			"passes exact check with any value in list",
+			args{
+				config.RuleTypeString{
+					Exact: config.StringList{"alice", "bob", "carol"},
+				},
+				"bob",
+			},
+			true,
+		},
+		{
+			"does not pass exact check with no value in list",
+			args{
+				config.RuleTypeString{
+					Exact: config.StringList{"alice", "bob", "carol"},
+				},
+				"dave",
+			},
+			false,
+		},
+		{
+			"does not pass no-exact check with any value in list",
+			args{
+				config.RuleTypeString{
+					NoExact: config.StringList{"alice", "bob"},
+				},
+				"bob",
+			},
+			false,
+		},
+		{
+			"passes match check with any pattern in list",
+			args{
+				config.RuleTypeString{
+					Match: config.StringList{"^(feature/)", "^(fix/)"},
+				},
+				"fix/broken-build",
+			},
+			true,
+		},
+		{
+			"does not pass no-match check with any pattern in list",
+			args{
+				config.RuleTypeString{
+					NoMatch: config.StringList{"^(feature/)", "^(fix/)"},
+				},
+				"fix/broken-build",
+			},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := RuleTypeStringValidator(tt.args.r, tt.args.s); got != tt.want {
+			got, err := RuleTypeStringValidator(tt.args.r, tt.args.s)
+			if err != nil {
+				t.Errorf("RuleTypeStringValidator() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("RuleTypeStringValidator() = %v, want %v", got, tt.want)
 			}
 		})
@@ -115,10 +171,10 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"Passes all checks",
 			args{
 				config.RuleTypeInt{
-					Exact:   5790,
-					NoExact: 4,
-					Match:   "^(57)",
-					NoMatch: "(91)$",
+					Exact:   config.IntList{5790},
+					NoExact: config.IntList{4},
+					Match:   config.StringList{"^(57)"},
+					NoMatch: config.StringList{"(91)$"},
 				},
 				5790,
 			},
@@ -128,7 +184,7 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"passes exact check",
 			args{
 				config.RuleTypeInt{
-					Exact: 23,
+					Exact: config.IntList{23},
 				},
 				23,
 			},
@@ -138,7 +194,7 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"does not pass exact check",
 			args{
 				config.RuleTypeInt{
-					Exact: 23,
+					Exact: config.IntList{23},
 				},
 				44,
 			},
@@ -148,7 +204,7 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"passes no-exact check",
 			args{
 				config.RuleTypeInt{
-					NoExact: 44,
+					NoExact: config.IntList{44},
 				},
 				32,
 			},
@@ -158,7 +214,7 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"does not pass no-exact check",
 			args{
 				config.RuleTypeInt{
-					NoExact: 44,
+					NoExact: config.IntList{44},
 				},
 				44,
 			},
@@ -168,7 +224,7 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"passes match check",
 			args{
 				config.RuleTypeInt{
-					Match: "^(35)",
+					Match: config.StringList{"^(35)"},
 				},
 				3566,
 			},
@@ -178,16 +234,40 @@ func TestRuleTypeIntValidator(t *testing.T) {
 			"does not pass no-match check",
 			args{
 				config.RuleTypeInt{
-					NoMatch: "(56)$",
+					NoMatch: config.StringList{"(56)$"},
 				},
 				3456,
 			},
 			false,
 		},
+		{
+			"passes exact check with any value in list",
+			args{
+				config.RuleTypeInt{
+					Exact: config.IntList{12, 23, 34},
+				},
+				23,
+			},
+			true,
+		},
+		{
+			"does not pass no-exact check with any value in list",
+			args{
+				config.RuleTypeInt{
+					NoExact: config.IntList{12, 23, 34},
+				},
+				34,
+			},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := RuleTypeIntValidator(tt.args.r, tt.args.i); got != tt.want {
+			got, err := RuleTypeIntValidator(tt.args.r, tt.args.i)
+			if err != nil {
+				t.Errorf("RuleTypeIntValidator() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("RuleTypeIntValidator() = %v, want %v", got, tt.want)
 			}
 		})
@@ -218,40 +298,40 @@ func TestRule_MatchAllRules(t *testing.T) {
 			Rule{
 				Label: "My Test Rule Label",
 				HeadRules: config.RuleTypeString{
-					Exact:   "octopus-hello-head",
-					NoExact: "world",
-					Match:   "^(octopus)",
-					NoMatch: "(lion)$",
+					Exact:   config.StringList{"octopus-hello-head"},
+					NoExact: config.StringList{"world"},
+					Match:   config.StringList{"^(octopus)"},
+					NoMatch: config.StringList{"(lion)$"},
 				},
 				BaseRules: config.RuleTypeString{
-					Exact:   "octopus-hello-base",
-					NoExact: "world",
-					Match:   "^(octopus)",
-					NoMatch: "(lion)$",
+					Exact:   config.StringList{"octopus-hello-base"},
+					NoExact: config.StringList{"world"},
+					Match:   config.StringList{"^(octopus)"},
+					NoMatch: config.StringList{"(lion)$"},
 				},
 				TitleRules: config.RuleTypeString{
-					Exact:   "Test PR Title",
-					NoExact: "world",
-					Match:   "^(Tes)",
-					NoMatch: "(PR)$",
+					Exact:   config.StringList{"Test PR Title"},
+					NoExact: config.StringList{"world"},
+					Match:   config.StringList{"^(Tes)"},
+					NoMatch: config.StringList{"(PR)$"},
 				},
 				BodyRules: config.RuleTypeString{
-					Exact:   "Test PR Body Text",
-					NoExact: "world",
-					Match:   "^(Tes)",
-					NoMatch: "(Body)$",
+					Exact:   config.StringList{"Test PR Body Text"},
+					NoExact: config.StringList{"world"},
+					Match:   config.StringList{"^(Tes)"},
+					NoMatch: config.StringList{"(Body)$"},
 				},
 				UserRules: config.RuleTypeString{
-					Exact:   "tanmancan",
-					NoExact: "world",
-					Match:   "^(tan)",
-					NoMatch: "(man)$",
+					Exact:   config.StringList{"tanmancan"},
+					NoExact: config.StringList{"world"},
+					Match:   config.StringList{"^(tan)"},
+					NoMatch: config.StringList{"(man)$"},
 				},
 				NumberRules: config.RuleTypeInt{
-					Exact:   5790,
-					NoExact: 4,
-					Match:   "^(57)",
-					NoMatch: "(91)$",
+					Exact:   config.IntList{5790},
+					NoExact: config.IntList{4},
+					Match:   config.StringList{"^(57)"},
+					NoMatch: config.StringList{"(91)$"},
 				},
 				UpdatedRules: config.RuleTypeDate{
 					DaysBefore: 9,
@@ -295,9 +375,825 @@ func TestRule_MatchAllRules(t *testing.T) {
 				UpdatedRules: tt.fields.UpdatedRules,
 				CreatedRules: tt.fields.CreatedRules,
 			}
-			if got := r.MatchAllRules(tt.args.pr); got != tt.want {
+			got, err := r.MatchAllRules(tt.args.pr)
+			if err != nil {
+				t.Errorf("Rule.MatchAllRules() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("Rule.MatchAllRules() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestRuleTypeStringValidator_invalidPattern(t *testing.T) {
+	r := config.RuleTypeString{
+		Match: config.StringList{"(unclosed"},
+	}
+	got, err := RuleTypeStringValidator(r, "unclosed")
+	if err == nil {
+		t.Errorf("RuleTypeStringValidator() should return an error for an invalid pattern")
+	}
+	if got != false {
+		t.Errorf("RuleTypeStringValidator() = %v, want false", got)
+	}
+}
+
+func TestRuleParser_collectsPrErrors(t *testing.T) {
+	config.YamlConfig.Rules = []config.YamlRuleGroup{
+		{
+			Label: "stale",
+			YamlRuleSet: config.YamlRuleSet{
+				Updated: config.RuleTypeDate{
+					DaysBefore: 1,
+				},
+			},
+		},
+	}
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+	prList := gitapi.ListPullsResponse{
+		{Number: 1, UpdatedAt: "not a timestamp"},
+		{Number: 2, UpdatedAt: time.Now().AddDate(0, 0, -5).Format(time.RFC3339)},
+		{Number: 3, UpdatedAt: "also not a timestamp"},
+	}
+
+	prLabels, err := RuleParser(prList)
+
+	if len(prLabels) != 1 || prLabels[0].Issue != 2 {
+		t.Errorf("RuleParser() = %v, want PR #2 to be labeled", prLabels)
+	}
+
+	prErrors, ok := err.(PrErrors)
+	if !ok {
+		t.Fatalf("RuleParser() error = %v, want PrErrors", err)
+	}
+	if len(prErrors) != 2 || prErrors[0].Issue != 1 || prErrors[1].Issue != 3 {
+		t.Errorf("RuleParser() errors = %v, want errors for PR #1 and #3", prErrors)
+	}
+}
+
+func TestRuleParser_removeWhenUnmatched(t *testing.T) {
+	config.YamlConfig.Rules = []config.YamlRuleGroup{
+		{
+			Label:               "to-master",
+			RemoveWhenUnmatched: true,
+			YamlRuleSet: config.YamlRuleSet{
+				Base: config.RuleTypeString{
+					Exact: config.StringList{"master"},
+				},
+			},
+		},
+		{
+			Label: "from-staging",
+			YamlRuleSet: config.YamlRuleSet{
+				Head: config.RuleTypeString{
+					Exact: config.StringList{"staging"},
+				},
+			},
+		},
+	}
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+	prList := gitapi.ListPullsResponse{
+		{
+			Number: 1,
+			Base:   gitapi.PrBranch{Ref: "master"},
+			Labels: []gitapi.PrIssueLabel{{Name: "to-master"}},
+		},
+		{
+			Number: 2,
+			Base:   gitapi.PrBranch{Ref: "develop"},
+			Head:   gitapi.PrBranch{Ref: "feature"},
+			Labels: []gitapi.PrIssueLabel{{Name: "to-master"}, {Name: "from-staging"}},
+		},
+		{
+			Number: 3,
+			Base:   gitapi.PrBranch{Ref: "master"},
+			Head:   gitapi.PrBranch{Ref: "staging"},
+		},
+	}
+
+	prLabels, err := RuleParser(prList)
+	if err != nil {
+		t.Fatalf("RuleParser() error = %v", err)
+	}
+
+	got := map[int]gitapi.PrLabel{}
+	for _, prLabel := range prLabels {
+		got[prLabel.Issue] = prLabel
+	}
+
+	if _, ok := got[1]; ok {
+		t.Errorf("RuleParser() PR #1 still matches and should not be updated, got %v", got[1])
+	}
+	if fmt.Sprint(got[2].Remove) != "[to-master]" || len(got[2].Labels) != 0 {
+		t.Errorf("RuleParser() PR #2 = %v, want to-master removed and from-staging kept", got[2])
+	}
+	if fmt.Sprint(got[3].Labels) != "[to-master from-staging]" || len(got[3].Remove) != 0 {
+		t.Errorf("RuleParser() PR #3 = %v, want to-master and from-staging added", got[3])
+	}
+}
+
+func TestRule_MatchConditionRules(t *testing.T) {
+	hotfixTitle := Rule{TitleRules: config.RuleTypeString{Match: config.StringList{"^(hotfix)"}}}
+	hotfixHead := Rule{HeadRules: config.RuleTypeString{Match: config.StringList{"^(hotfix/)"}}}
+	toDevelop := Rule{BaseRules: config.RuleTypeString{Exact: config.StringList{"develop"}}}
+	tests := []struct {
+		name string
+		rule Rule
+		pr   gitapi.PullRequest
+		want bool
+	}{
+		{
+			"any matches first condition",
+			Rule{AnyRules: []Rule{hotfixTitle, hotfixHead}},
+			gitapi.PullRequest{Title: "hotfix: broken build", Head: gitapi.PrBranch{Ref: "fix-build"}},
+			true,
+		},
+		{
+			"any matches second condition",
+			Rule{AnyRules: []Rule{hotfixTitle, hotfixHead}},
+			gitapi.PullRequest{Title: "Fix broken build", Head: gitapi.PrBranch{Ref: "hotfix/build"}},
+			true,
+		},
+		{
+			"any matches no conditions",
+			Rule{AnyRules: []Rule{hotfixTitle, hotfixHead}},
+			gitapi.PullRequest{Title: "Fix broken build", Head: gitapi.PrBranch{Ref: "fix-build"}},
+			false,
+		},
+		{
+			"all requires every condition",
+			Rule{AllRules: []Rule{hotfixTitle, hotfixHead}},
+			gitapi.PullRequest{Title: "hotfix: broken build", Head: gitapi.PrBranch{Ref: "fix-build"}},
+			false,
+		},
+		{
+			"not excludes matching condition",
+			Rule{AnyRules: []Rule{hotfixTitle, hotfixHead}, NotRules: &toDevelop},
+			gitapi.PullRequest{Title: "hotfix: broken build", Base: gitapi.PrBranch{Ref: "develop"}},
+			false,
+		},
+		{
+			"nested blocks",
+			Rule{
+				TitleRules: config.RuleTypeString{NoMatch: config.StringList{"WIP"}},
+				AllRules: []Rule{
+					{AnyRules: []Rule{hotfixTitle, hotfixHead}},
+					{NotRules: &toDevelop},
+				},
+			},
+			gitapi.PullRequest{Title: "Fix broken build", Head: gitapi.PrBranch{Ref: "hotfix/build"}, Base: gitapi.PrBranch{Ref: "master"}},
+			true,
+		},
+		{
+			"empty blocks match",
+			Rule{},
+			gitapi.PullRequest{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.MatchAllRules(tt.pr)
+			if err != nil {
+				t.Errorf("Rule.MatchAllRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchAllRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchFileRules(t *testing.T) {
+	pr := gitapi.PullRequest{
+		Files: []string{"docs/readme.md", "src/app.ts", "www/index.html"},
+	}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeString
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeString{}, true},
+		{"exact passes with any listed file", config.RuleTypeString{Exact: config.StringList{"www/robots.txt", "www/index.html"}}, true},
+		{"exact fails without listed files", config.RuleTypeString{Exact: config.StringList{"www/robots.txt", "www/404.html"}}, false},
+		{"no-exact fails with any listed file", config.RuleTypeString{NoExact: config.StringList{"www/robots.txt", "src/app.ts"}}, false},
+		{"no-exact passes without listed files", config.RuleTypeString{NoExact: config.StringList{"www/robots.txt"}}, true},
+		{"match passes with any pattern", config.RuleTypeString{Match: config.StringList{"(.jpg)$", "(.ts)$"}}, true},
+		{"match fails without matching patterns", config.RuleTypeString{Match: config.StringList{"(.jpg)$", "(.png)$"}}, false},
+		{"no-match fails with any pattern", config.RuleTypeString{NoMatch: config.StringList{"(.jpg)$", "^(docs/)"}}, false},
+		{"no-match passes without matching patterns", config.RuleTypeString{NoMatch: config.StringList{"(.jpg)$"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{FileRules: config.RuleTypeFile{RuleTypeString: tt.rules}}
+			got, err := r.MatchFileRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchFileRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchFileRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchFileRules_glob(t *testing.T) {
+	pr := gitapi.PullRequest{
+		Files: []string{"docs/readme.md", "src/frontend/components/App.tsx", "src/server/main.go"},
+	}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeFile
+		want  bool
+	}{
+		{"glob passes with matching pattern", config.RuleTypeFile{Glob: config.StringList{"src/frontend/**/*.tsx"}}, true},
+		{"glob passes with any pattern in list", config.RuleTypeFile{Glob: config.StringList{"*.css", "*.go"}}, true},
+		{"glob fails without matching pattern", config.RuleTypeFile{Glob: config.StringList{"*.css"}}, false},
+		{"no-glob fails with matching pattern", config.RuleTypeFile{NoGlob: config.StringList{"docs/"}}, false},
+		{"no-glob passes without matching pattern", config.RuleTypeFile{NoGlob: config.StringList{"/vendor/"}}, true},
+		{
+			"glob combined with string checks",
+			config.RuleTypeFile{
+				RuleTypeString: config.RuleTypeString{NoExact: config.StringList{"src/server/main.go"}},
+				Glob:           config.StringList{"*.tsx"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{FileRules: tt.rules}
+			got, err := r.MatchFileRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchFileRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchFileRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchFileRules_quantifier(t *testing.T) {
+	docsPr := gitapi.PullRequest{
+		Files: []string{"docs/install.md", "docs/readme.md"},
+	}
+	mixedPr := gitapi.PullRequest{
+		Files: []string{"docs/readme.md", "migrations/001.sql", "migrations/002.sql", "src/app.ts"},
+	}
+	emptyPr := gitapi.PullRequest{}
+	tests := []struct {
+		name  string
+		pr    gitapi.PullRequest
+		rules config.RuleTypeFile
+		want  bool
+	}{
+		{"any passes with one matching file", mixedPr, config.RuleTypeFile{Glob: config.StringList{"docs/"}, Quantifier: config.QuantifierAny}, true},
+		{"all passes when every file matches", docsPr, config.RuleTypeFile{Glob: config.StringList{"docs/"}, Quantifier: config.QuantifierAll}, true},
+		{"all fails when some files do not match", mixedPr, config.RuleTypeFile{Glob: config.StringList{"docs/"}, Quantifier: config.QuantifierAll}, false},
+		{"all fails without changed files", emptyPr, config.RuleTypeFile{Glob: config.StringList{"docs/"}, Quantifier: config.QuantifierAll}, false},
+		{"all applies to match check", docsPr, config.RuleTypeFile{RuleTypeString: config.RuleTypeString{Match: config.StringList{"(.md)$"}}, Quantifier: config.QuantifierAll}, true},
+		{"all applies to exact check", docsPr, config.RuleTypeFile{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"docs/readme.md"}}, Quantifier: config.QuantifierAll}, false},
+		{"none passes without matching files", docsPr, config.RuleTypeFile{Glob: config.StringList{"*.sql"}, Quantifier: config.QuantifierNone}, true},
+		{"none fails with a matching file", mixedPr, config.RuleTypeFile{Glob: config.StringList{"*.sql"}, Quantifier: config.QuantifierNone}, false},
+		{"min-count passes with enough matching files", mixedPr, config.RuleTypeFile{Glob: config.StringList{"migrations/*.sql"}, MinCount: 2}, true},
+		{"min-count fails without enough matching files", mixedPr, config.RuleTypeFile{Glob: config.StringList{"migrations/*.sql"}, MinCount: 3}, false},
+		{"min-count with all quantifier", docsPr, config.RuleTypeFile{Glob: config.StringList{"*.md"}, Quantifier: config.QuantifierAll, MinCount: 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{FileRules: tt.rules}
+			got, err := r.MatchFileRules(tt.pr)
+			if err != nil {
+				t.Errorf("Rule.MatchFileRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchFileRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchFileRules_status(t *testing.T) {
+	pr := gitapi.PullRequest{
+		FileDetails: gitapi.ListPrFilesResponse{
+			{Filename: "docs/readme.md", PreviousFilename: "readme.md", Status: "renamed"},
+			{Filename: "migrations/001.sql", Status: "modified"},
+			{Filename: "migrations/002.sql", Status: "added"},
+			{Filename: "src/app.ts", Status: "removed"},
+		},
+	}
+	pr.Files = pr.FileDetails.Filenames()
+	migrations := config.StringList{"migrations/*.sql"}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeFile
+		want  bool
+	}{
+		{"status only requires a file with the status", config.RuleTypeFile{Status: config.FileStatuses{"removed"}}, true},
+		{"status only fails without a file with the status", config.RuleTypeFile{Status: config.FileStatuses{"copied"}}, false},
+		{"added migration", config.RuleTypeFile{Glob: migrations, Status: config.FileStatuses{"added"}}, true},
+		{"added migrations min-count", config.RuleTypeFile{Glob: migrations, Status: config.FileStatuses{"added"}, MinCount: 2}, false},
+		{"all modified files", config.RuleTypeFile{Glob: migrations, Status: config.FileStatuses{"modified", "added"}, Quantifier: config.QuantifierAll}, true},
+		{"no-glob only checks files with the status", config.RuleTypeFile{NoGlob: config.StringList{"src/"}, Status: config.FileStatuses{"added"}}, true},
+		{"previous filename not checked by default", config.RuleTypeFile{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"readme.md"}}}, false},
+		{"previous filename", config.RuleTypeFile{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"readme.md"}}, PreviousFilename: true}, true},
+		{"renamed from path", config.RuleTypeFile{Glob: config.StringList{"/*.md"}, Status: config.FileStatuses{"renamed"}, PreviousFilename: true, Quantifier: config.QuantifierAll}, true},
+		{"no-exact previous filename", config.RuleTypeFile{RuleTypeString: config.RuleTypeString{NoExact: config.StringList{"readme.md"}}, PreviousFilename: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{FileRules: tt.rules}
+			got, err := r.MatchFileRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchFileRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchFileRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Returns a pointer to an int value for count checks
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRuleTypeCountValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules config.RuleTypeCount
+		value int
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeCount{}, 10, true},
+		{"gt passes above value", config.RuleTypeCount{Gt: intPtr(9)}, 10, true},
+		{"gt fails at value", config.RuleTypeCount{Gt: intPtr(10)}, 10, false},
+		{"gte passes at value", config.RuleTypeCount{Gte: intPtr(10)}, 10, true},
+		{"gte fails below value", config.RuleTypeCount{Gte: intPtr(11)}, 10, false},
+		{"lt passes below value", config.RuleTypeCount{Lt: intPtr(11)}, 10, true},
+		{"lt fails at value", config.RuleTypeCount{Lt: intPtr(10)}, 10, false},
+		{"lte passes at value", config.RuleTypeCount{Lte: intPtr(10)}, 10, true},
+		{"lte fails above value", config.RuleTypeCount{Lte: intPtr(9)}, 10, false},
+		{"gt zero fails at zero", config.RuleTypeCount{Gt: intPtr(0)}, 0, false},
+		{"range passes within bounds", config.RuleTypeCount{Gte: intPtr(10), Lt: intPtr(100)}, 50, true},
+		{"range fails outside bounds", config.RuleTypeCount{Gte: intPtr(10), Lt: intPtr(100)}, 100, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RuleTypeCountValidator(tt.rules, tt.value); got != tt.want {
+				t.Errorf("RuleTypeCountValidator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchSizeRules(t *testing.T) {
+	pr := gitapi.PullRequest{
+		Additions:    600,
+		Deletions:    100,
+		ChangedFiles: 3,
+		FileDetails: gitapi.ListPrFilesResponse{
+			{Filename: "go.sum", Additions: 500, Deletions: 80},
+			{Filename: "src/app.ts", Additions: 60, Deletions: 10},
+			{Filename: "vendor/lib/lib.go", Additions: 40, Deletions: 10},
+		},
+	}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeSize
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeSize{}, true},
+		{"additions check", config.RuleTypeSize{Additions: config.RuleTypeCount{Gt: intPtr(500)}}, true},
+		{"deletions check", config.RuleTypeSize{Deletions: config.RuleTypeCount{Lt: intPtr(100)}}, false},
+		{"total check", config.RuleTypeSize{Total: config.RuleTypeCount{Gte: intPtr(700)}}, true},
+		{"files check", config.RuleTypeSize{Files: config.RuleTypeCount{Lte: intPtr(2)}}, false},
+		{
+			"excluded files are not counted",
+			config.RuleTypeSize{Total: config.RuleTypeCount{Lt: intPtr(100)}, Exclude: config.StringList{"go.sum", "vendor/"}},
+			true,
+		},
+		{
+			"excluded files are not counted in files check",
+			config.RuleTypeSize{Files: config.RuleTypeCount{Gt: intPtr(1)}, Exclude: config.StringList{"go.sum", "vendor/"}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{SizeRules: tt.rules}
+			got, err := r.MatchSizeRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchSizeRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchSizeRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_requirements(t *testing.T) {
+	sizeRule := config.RuleTypeSize{Total: config.RuleTypeCount{Gt: intPtr(10)}}
+	excludeRule := config.RuleTypeSize{Total: config.RuleTypeCount{Gt: intPtr(10)}, Exclude: config.StringList{"vendor/"}}
+	fileRule := config.RuleTypeFile{Glob: config.StringList{"docs/"}}
+	tests := []struct {
+		name string
+		rule Rule
+		want prRequirements
+	}{
+		{"no requirements", Rule{TitleRules: config.RuleTypeString{Exact: config.StringList{"a"}}}, prRequirements{}},
+		{"file rule requires files", Rule{FileRules: fileRule}, prRequirements{files: true}},
+		{"size rule requires details", Rule{SizeRules: sizeRule}, prRequirements{details: true}},
+		{"size rule with exclude requires files", Rule{SizeRules: excludeRule}, prRequirements{files: true}},
+		{"review rule requires reviews", Rule{ReviewRules: config.RuleTypeReview{State: config.ReviewStates{"approved"}}}, prRequirements{reviews: true}},
+		{"checks rule requires checks", Rule{ChecksRules: config.RuleTypeChecks{State: config.ChecksStates{"failure"}}}, prRequirements{checks: true}},
+		{"mergeable rule requires mergeable state", Rule{MergeableRules: config.RuleTypeMergeable{State: config.StringList{"dirty"}}}, prRequirements{mergeable: true}},
+		{"commit rule requires commits", Rule{CommitRules: config.RuleTypeCommit{Message: config.RuleTypeString{Match: config.StringList{"^fix"}}}}, prRequirements{commits: true}},
+		{"comment rule requires comments", Rule{CommentRules: config.RuleTypeComment{Text: config.RuleTypeString{Exact: config.StringList{"LGTM"}}}}, prRequirements{comments: true}},
+		{"comment filters require comments", Rule{CommentRules: config.RuleTypeComment{Maintainers: true}}, prRequirements{comments: true}},
+		{"issue rule requires issues", Rule{IssueRules: config.RuleTypeIssue{State: config.StringList{"open"}}}, prRequirements{issues: true}},
+		{"inherit-labels requires issues", Rule{InheritLabels: config.RuleTypeString{Match: config.StringList{"^area/"}}}, prRequirements{issues: true}},
+		{
+			"nested condition blocks",
+			Rule{AnyRules: []Rule{{FileRules: fileRule}}, NotRules: &Rule{SizeRules: sizeRule}},
+			prRequirements{files: true, details: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.requirements(); got != tt.want {
+				t.Errorf("Rule.requirements() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchDraftRules(t *testing.T) {
+	isDraft := true
+	notDraft := false
+	tests := []struct {
+		name  string
+		rules *bool
+		draft bool
+		want  bool
+	}{
+		{"empty rule passes draft", nil, true, true},
+		{"empty rule passes ready", nil, false, true},
+		{"true matches draft", &isDraft, true, true},
+		{"true does not match ready", &isDraft, false, false},
+		{"false matches ready", &notDraft, false, true},
+		{"false does not match draft", &notDraft, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{DraftRules: tt.rules}
+			got, err := r.MatchDraftRules(gitapi.PullRequest{Draft: tt.draft})
+			if err != nil {
+				t.Errorf("Rule.MatchDraftRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchDraftRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleTypeListValidator(t *testing.T) {
+	labels := []string{"bug", "priority/high", "frontend"}
+	isEmpty := true
+	notEmpty := false
+	tests := []struct {
+		name   string
+		rules  config.RuleTypeString
+		empty  *bool
+		values []string
+		want   bool
+	}{
+		{"empty rule passes", config.RuleTypeString{}, nil, labels, true},
+		{"empty rule passes without values", config.RuleTypeString{}, nil, nil, true},
+		{"exact passes with any value", config.RuleTypeString{Exact: config.StringList{"feature", "bug"}}, nil, labels, true},
+		{"exact fails without values", config.RuleTypeString{Exact: config.StringList{"bug"}}, nil, nil, false},
+		{"no-exact fails with any value", config.RuleTypeString{NoExact: config.StringList{"do-not-merge", "frontend"}}, nil, labels, false},
+		{"no-exact passes without values", config.RuleTypeString{NoExact: config.StringList{"do-not-merge"}}, nil, nil, true},
+		{"match passes with any value", config.RuleTypeString{Match: config.StringList{"^(priority/)"}}, nil, labels, true},
+		{"match fails without matching values", config.RuleTypeString{Match: config.StringList{"^(size/)"}}, nil, labels, false},
+		{"no-match fails with any value", config.RuleTypeString{NoMatch: config.StringList{"^(priority/)"}}, nil, labels, false},
+		{"no-match passes without matching values", config.RuleTypeString{NoMatch: config.StringList{"^(size/)"}}, nil, labels, true},
+		{"empty passes without values", config.RuleTypeString{}, &isEmpty, nil, true},
+		{"empty fails with values", config.RuleTypeString{}, &isEmpty, labels, false},
+		{"not empty passes with values", config.RuleTypeString{}, &notEmpty, labels, true},
+		{"not empty fails without values", config.RuleTypeString{}, &notEmpty, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RuleTypeListValidator(config.RuleTypeList{RuleTypeString: tt.rules, Empty: tt.empty}, tt.values)
+			if err != nil {
+				t.Errorf("RuleTypeListValidator() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RuleTypeListValidator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleParser_cascadeLabels(t *testing.T) {
+	config.YamlConfig.Rules = []config.YamlRuleGroup{
+		{
+			Label:               "to-master",
+			RemoveWhenUnmatched: true,
+			YamlRuleSet: config.YamlRuleSet{
+				Base: config.RuleTypeString{Exact: config.StringList{"master"}},
+			},
+		},
+		{
+			Label: "needs-release-notes",
+			YamlRuleSet: config.YamlRuleSet{
+				Labels: config.RuleTypeList{RuleTypeString: config.RuleTypeString{
+					Exact:   config.StringList{"to-master"},
+					NoExact: config.StringList{"do-not-merge"},
+				}},
+			},
+		},
+		{
+			Label: "unreleased",
+			YamlRuleSet: config.YamlRuleSet{
+				Labels: config.RuleTypeList{RuleTypeString: config.RuleTypeString{NoExact: config.StringList{"to-master"}}},
+			},
+		},
+		{
+			Label: "to-master",
+			YamlRuleSet: config.YamlRuleSet{
+				Head: config.RuleTypeString{Exact: config.StringList{"release"}},
+			},
+		},
+	}
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+	prList := gitapi.ListPullsResponse{
+		{Number: 1, Base: gitapi.PrBranch{Ref: "master"}},
+		{Number: 2, Base: gitapi.PrBranch{Ref: "master"}, Labels: []gitapi.PrIssueLabel{{Name: "do-not-merge"}}},
+		{Number: 3, Base: gitapi.PrBranch{Ref: "develop"}, Labels: []gitapi.PrIssueLabel{{Name: "to-master"}}},
+		{
+			Number: 4,
+			Base:   gitapi.PrBranch{Ref: "develop"},
+			Head:   gitapi.PrBranch{Ref: "release"},
+			Labels: []gitapi.PrIssueLabel{{Name: "to-master"}, {Name: "unreleased"}},
+		},
+	}
+
+	prLabels, err := RuleParser(prList)
+	if err != nil {
+		t.Fatalf("RuleParser() error = %v", err)
+	}
+
+	got := map[int]gitapi.PrLabel{}
+	for _, prLabel := range prLabels {
+		got[prLabel.Issue] = prLabel
+	}
+
+	if fmt.Sprint(got[1].Labels) != "[to-master needs-release-notes]" {
+		t.Errorf("RuleParser() PR #1 = %v, want labels added by earlier rules to be checked", got[1])
+	}
+	if fmt.Sprint(got[2].Labels) != "[to-master]" {
+		t.Errorf("RuleParser() PR #2 = %v, want existing labels to be checked", got[2])
+	}
+	if fmt.Sprint(got[3].Remove) != "[to-master]" || fmt.Sprint(got[3].Labels) != "[unreleased]" {
+		t.Errorf("RuleParser() PR #3 = %v, want labels removed by earlier rules to be checked", got[3])
+	}
+	if fmt.Sprint(got[4].Labels) != "[needs-release-notes]" || len(got[4].Remove) != 0 {
+		t.Errorf("RuleParser() PR #4 = %v, want label matched by a later group to be kept and checked", got[4])
+	}
+}
+
+func TestRuleParser_sharedLabel(t *testing.T) {
+	matching := config.YamlRuleSet{Head: config.RuleTypeString{Exact: config.StringList{"hotfix"}}}
+	unmatched := config.YamlRuleSet{Base: config.RuleTypeString{Exact: config.StringList{"release"}}}
+	tests := []struct {
+		name       string
+		rules      []config.YamlRuleGroup
+		labels     []gitapi.PrIssueLabel
+		wantLabels string
+		wantRemove string
+	}{
+		{
+			"unmatched group after matching group does not cancel add",
+			[]config.YamlRuleGroup{
+				{Label: "urgent", YamlRuleSet: matching},
+				{Label: "urgent", RemoveWhenUnmatched: true, YamlRuleSet: unmatched},
+			},
+			nil,
+			"[urgent]",
+			"[]",
+		},
+		{
+			"unmatched group before matching group does not remove",
+			[]config.YamlRuleGroup{
+				{Label: "urgent", RemoveWhenUnmatched: true, YamlRuleSet: unmatched},
+				{Label: "urgent", YamlRuleSet: matching},
+			},
+			[]gitapi.PrIssueLabel{{Name: "urgent"}},
+			"[]",
+			"[]",
+		},
+		{
+			"removed once when no group matches",
+			[]config.YamlRuleGroup{
+				{Label: "urgent", RemoveWhenUnmatched: true, YamlRuleSet: unmatched},
+				{Label: "urgent", RemoveWhenUnmatched: true, YamlRuleSet: unmatched},
+			},
+			[]gitapi.PrIssueLabel{{Name: "urgent"}},
+			"[]",
+			"[urgent]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.YamlConfig.Rules = tt.rules
+			t.Cleanup(func() {
+				config.YamlConfig = config.YamlConfigV1{}
+			})
+			prList := gitapi.ListPullsResponse{
+				{Number: 1, Head: gitapi.PrBranch{Ref: "hotfix"}, Base: gitapi.PrBranch{Ref: "main"}, Labels: tt.labels},
+			}
+
+			prLabels, err := RuleParser(prList)
+			if err != nil {
+				t.Fatalf("RuleParser() error = %v", err)
+			}
+
+			got := gitapi.PrLabel{Labels: []string{}, Remove: []string{}}
+			if len(prLabels) > 0 {
+				got = prLabels[0]
+			}
+			if fmt.Sprint(got.Labels) != tt.wantLabels || fmt.Sprint(got.Remove) != tt.wantRemove {
+				t.Errorf("RuleParser() = %v, want labels %v and remove %v", got, tt.wantLabels, tt.wantRemove)
+			}
+		})
+	}
+}
+
+func TestRule_MatchReviewerAssigneeRules(t *testing.T) {
+	config.YamlConfig.Owner = "octocat"
+	t.Cleanup(func() {
+		config.YamlConfig = config.YamlConfigV1{}
+	})
+	isEmpty := true
+	pr := gitapi.PullRequest{
+		Reviewers: []gitapi.PrUser{{Login: "hubot"}},
+		Teams:     []gitapi.PrTeam{{Name: "Frontend Team", Slug: "frontend-team"}},
+	}
+	tests := []struct {
+		name string
+		rule Rule
+		want bool
+	}{
+		{"reviewer login", Rule{ReviewerRules: config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"hubot"}}}}, true},
+		{"reviewer team", Rule{ReviewerRules: config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"octocat/frontend-team"}}}}, true},
+		{"reviewer team requires owner", Rule{ReviewerRules: config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"frontend-team"}}}}, false},
+		{"reviewer match", Rule{ReviewerRules: config.RuleTypeList{RuleTypeString: config.RuleTypeString{Match: config.StringList{"/backend-"}}}}, false},
+		{"reviewers not empty", Rule{ReviewerRules: config.RuleTypeList{Empty: &isEmpty}}, false},
+		{"assignees empty", Rule{AssigneeRules: config.RuleTypeList{Empty: &isEmpty}}, true},
+		{"assignee login", Rule{AssigneeRules: config.RuleTypeList{RuleTypeString: config.RuleTypeString{Exact: config.StringList{"hubot"}}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.MatchAllRules(pr)
+			if err != nil {
+				t.Errorf("Rule.MatchAllRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchAllRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchMilestoneRules(t *testing.T) {
+	isEmpty := true
+	tests := []struct {
+		name  string
+		rules config.RuleTypeList
+		pr    gitapi.PullRequest
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeList{}, gitapi.PullRequest{}, true},
+		{"milestone title", config.RuleTypeList{RuleTypeString: config.RuleTypeString{Match: config.StringList{"^v2"}}}, gitapi.PullRequest{Milestone: &gitapi.PrMilestone{Title: "v2.1"}}, true},
+		{"missing milestone", config.RuleTypeList{RuleTypeString: config.RuleTypeString{Match: config.StringList{"^v2"}}}, gitapi.PullRequest{}, false},
+		{"without milestone", config.RuleTypeList{Empty: &isEmpty}, gitapi.PullRequest{}, true},
+		{"with milestone", config.RuleTypeList{Empty: &isEmpty}, gitapi.PullRequest{Milestone: &gitapi.PrMilestone{Title: "v2.1"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{MilestoneRules: tt.rules}
+			got, err := r.MatchMilestoneRules(tt.pr)
+			if err != nil {
+				t.Errorf("Rule.MatchMilestoneRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchMilestoneRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchAssociationRules(t *testing.T) {
+	r := Rule{AssociationRules: config.RuleTypeString{Exact: config.StringList{"FIRST_TIME_CONTRIBUTOR", "FIRST_TIMER"}}}
+
+	got, err := r.MatchAssociationRules(gitapi.PullRequest{Association: "FIRST_TIMER"})
+	if err != nil || got != true {
+		t.Errorf("Rule.MatchAssociationRules() = %v, %v, want true", got, err)
+	}
+
+	got, err = r.MatchAssociationRules(gitapi.PullRequest{Association: "MEMBER"})
+	if err != nil || got != false {
+		t.Errorf("Rule.MatchAssociationRules() = %v, %v, want false", got, err)
+	}
+}
+
+func TestRule_MatchMergeableRules(t *testing.T) {
+	isTrue := true
+	isFalse := false
+	conflictPr := gitapi.PullRequest{Mergeable: &isFalse, MergeableState: "dirty"}
+	cleanPr := gitapi.PullRequest{Mergeable: &isTrue, MergeableState: "clean"}
+	unknownPr := gitapi.PullRequest{MergeableState: "unknown"}
+	tests := []struct {
+		name  string
+		rules config.RuleTypeMergeable
+		pr    gitapi.PullRequest
+		want  bool
+	}{
+		{"empty rule passes", config.RuleTypeMergeable{}, unknownPr, true},
+		{"conflicts matches conflicting", config.RuleTypeMergeable{Conflicts: &isTrue}, conflictPr, true},
+		{"conflicts does not match clean", config.RuleTypeMergeable{Conflicts: &isTrue}, cleanPr, false},
+		{"no conflicts matches clean", config.RuleTypeMergeable{Conflicts: &isFalse}, cleanPr, true},
+		{"unknown does not match conflicts", config.RuleTypeMergeable{Conflicts: &isTrue}, unknownPr, false},
+		{"unknown does not match no conflicts", config.RuleTypeMergeable{Conflicts: &isFalse}, unknownPr, false},
+		{"state matches", config.RuleTypeMergeable{State: config.StringList{"blocked", "clean"}}, cleanPr, true},
+		{"state does not match", config.RuleTypeMergeable{State: config.StringList{"behind"}}, cleanPr, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{MergeableRules: tt.rules}
+			got, err := r.MatchMergeableRules(tt.pr)
+			if err != nil {
+				t.Errorf("Rule.MatchMergeableRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchMergeableRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_MatchTeamRules(t *testing.T) {
+	teams := map[string][]string{
+		"octo-org/platform": {"octocat"},
+		"octo-org/frontend": {"hubot"},
+	}
+	lookup := isTeamMember
+	isTeamMember = func(team string, login string) (bool, error) {
+		if _, found := teams[team]; found == false {
+			return false, errors.New("Not Found")
+		}
+		return config.StringList(teams[team]).Contains(login), nil
+	}
+	t.Cleanup(func() {
+		isTeamMember = lookup
+	})
+	pr := gitapi.PullRequest{User: gitapi.PrUser{Login: "octocat"}}
+	tests := []struct {
+		name    string
+		rules   config.RuleTypeTeam
+		want    bool
+		wantErr bool
+	}{
+		{"empty rule passes", config.RuleTypeTeam{}, true, false},
+		{"member of any team", config.RuleTypeTeam{Member: config.StringList{"octo-org/frontend", "octo-org/platform"}}, true, false},
+		{"not a member", config.RuleTypeTeam{Member: config.StringList{"octo-org/frontend"}}, false, false},
+		{"no-member fails for member", config.RuleTypeTeam{NoMember: config.StringList{"octo-org/platform"}}, false, false},
+		{"no-member passes for non member", config.RuleTypeTeam{NoMember: config.StringList{"octo-org/frontend"}}, true, false},
+		{"lookup error", config.RuleTypeTeam{Member: config.StringList{"octo-org/missing"}}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Rule{TeamRules: tt.rules}
+			got, err := r.MatchTeamRules(pr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rule.MatchTeamRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Rule.MatchTeamRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}